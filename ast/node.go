@@ -0,0 +1,110 @@
+// Package ast represents jsondoc text as a tree of nodes with comments attached,
+// for renderers and tools that work on the document structure.
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Kind is the kind of a JSON value.
+type Kind int
+
+const (
+	Null Kind = iota
+	Bool
+	Number
+	String
+	Object
+	Array
+)
+
+var kindNames = [...]string{"null", "bool", "number", "string", "object", "array"}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "invalid"
+}
+
+// A Node is a JSON value in a jsondoc document.
+type Node struct {
+	Kind Kind
+	// Key is the object key of the node, if it's a member of an object.
+	Key string
+	// Value is the literal of a Null, Bool, Number or String node, as it is in the source.
+	Value string
+	// Children are the members of an Object node, or the elements of an Array node.
+	Children []*Node
+	// Leading are the comments on their own lines before the node, or before its key.
+	Leading []string
+	// Comment is the comment after the node on the same line;
+	// for Object and Array nodes, it's the comment after the opening bracket.
+	Comment string
+	// EndComment is the comment after the closing bracket of an Object or Array node on the same line.
+	EndComment string
+	// Dangling are the comments on their own lines after the last child of an Object or Array node.
+	Dangling []string
+	// Trailing are the comments on their own lines after the top-level node.
+	Trailing []string
+}
+
+// IsScalar reports whether the node is not an object or array.
+func (n *Node) IsScalar() bool {
+	return n.Kind != Object && n.Kind != Array
+}
+
+// CommentText returns all the comments of the node except Dangling and Trailing ones, joined by spaces,
+// for renderers that write one comment per node.
+func (n *Node) CommentText() string {
+	var text string
+	for _, c := range n.Leading {
+		text = joinComments(text, c)
+	}
+	return joinComments(joinComments(text, n.Comment), n.EndComment)
+}
+
+// Text returns the unquoted value of a String node, or the Value of other scalar nodes.
+func (n *Node) Text() string {
+	if n.Kind == String {
+		var s string
+		if json.Unmarshal([]byte(n.Value), &s) == nil {
+			return s
+		}
+	}
+	return n.Value
+}
+
+// MarshalJSON returns the node as compact JSON without comments.
+func (n *Node) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	n.writeJSON(&buf)
+	return buf.Bytes(), nil
+}
+
+func (n *Node) writeJSON(buf *bytes.Buffer) {
+	switch n.Kind {
+	case Object, Array:
+	default:
+		buf.WriteString(n.Value)
+		return
+	}
+	open, end := byte('{'), byte('}')
+	if n.Kind == Array {
+		open, end = '[', ']'
+	}
+	buf.WriteByte(open)
+	for i, child := range n.Children {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if n.Kind == Object {
+			key, _ := json.Marshal(child.Key)
+			buf.Write(key)
+			buf.WriteByte(':')
+		}
+		child.writeJSON(buf)
+	}
+	buf.WriteByte(end)
+}
@@ -0,0 +1,167 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/lovego/jsondoc/scanner"
+)
+
+// Parse parses jsondoc text, like the output of MarshalIndent, into a tree of nodes.
+// Comments on their own lines are attached to the node after them as Leading comments,
+// or to the enclosing node as Dangling comments if there is no node after them;
+// comments on the same line are attached to the node before them.
+// Comments on their own lines after the top-level node are its Trailing comments.
+func Parse(data []byte) (*Node, error) {
+	if err := scanner.Validate(data); err != nil {
+		return nil, err
+	}
+	p := parser{data: data}
+	leading := p.comments()
+	n := p.value()
+	n.Leading = leading
+	p.trailingComment(n)
+	n.Trailing = p.comments()
+	return n, nil
+}
+
+// parser is a recursive descent parser for validated jsondoc text.
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) value() *Node {
+	switch c := p.data[p.pos]; c {
+	case '{':
+		return p.composite(Object, '}')
+	case '[':
+		return p.composite(Array, ']')
+	case '"':
+		start := p.pos
+		p.pos++
+		for p.data[p.pos] != '"' {
+			if p.data[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		p.pos++
+		return &Node{Kind: String, Value: string(p.data[start:p.pos])}
+	default:
+		start := p.pos
+		for p.pos < len(p.data) && !strings.ContainsRune(",:]}# \t\r\n", rune(p.data[p.pos])) {
+			p.pos++
+		}
+		n := &Node{Kind: Number, Value: string(p.data[start:p.pos])}
+		switch c {
+		case 't', 'f':
+			n.Kind = Bool
+		case 'n':
+			n.Kind = Null
+		}
+		return n
+	}
+}
+
+func (p *parser) composite(kind Kind, end byte) *Node {
+	n := &Node{Kind: kind}
+	p.pos++
+	n.Comment = p.comment()
+	for {
+		leading := p.comments()
+		if p.data[p.pos] == end {
+			p.pos++
+			n.Dangling = leading
+			return n
+		}
+		var key string
+		if kind == Object {
+			key = p.value().Text()
+			// comments between the key and the value are kept before the key.
+			leading = append(leading, p.comments()...)
+			p.pos++ // skip ':'
+			leading = append(leading, p.comments()...)
+		}
+		child := p.value()
+		child.Key = key
+		child.Leading = leading
+		p.trailingComment(child)
+		n.Children = append(n.Children, child)
+	}
+}
+
+// trailingComment attaches the comments after a value and before or after its comma to it.
+func (p *parser) trailingComment(n *Node) {
+	comment := p.comment()
+	if start := p.pos; p.pos < len(p.data) {
+		// comments on their own lines between the value and its comma.
+		between := p.comments()
+		if p.pos < len(p.data) && p.data[p.pos] == ',' {
+			for _, c := range between {
+				comment = joinComments(comment, c)
+			}
+			p.pos++
+			comment = joinComments(comment, p.comment())
+		} else {
+			p.pos = start
+		}
+	}
+	if n.IsScalar() {
+		n.Comment = comment
+	} else {
+		n.EndComment = comment
+	}
+}
+
+// comment returns the comment on the same line, if any.
+func (p *parser) comment() string {
+	p.skipSpace(false)
+	if p.pos >= len(p.data) || p.data[p.pos] != '#' {
+		return ""
+	}
+	start := p.pos + 1
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		p.pos++
+	}
+	comment := strings.TrimSpace(string(p.data[start:p.pos]))
+	if p.pos < len(p.data) {
+		p.pos++ // skip '\n'
+	}
+	return comment
+}
+
+// comments skips spaces and returns the comments on their own lines.
+func (p *parser) comments() (comments []string) {
+	for {
+		p.skipSpace(true)
+		if p.pos >= len(p.data) || p.data[p.pos] != '#' {
+			return
+		}
+		comments = append(comments, p.comment())
+	}
+}
+
+func (p *parser) skipSpace(newline bool) {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r':
+		case '\n':
+			if !newline {
+				return
+			}
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+func joinComments(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + " " + b
+}
@@ -0,0 +1,180 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/lovego/jsondoc/internal/width"
+)
+
+// Print appends to dst the jsondoc text of n, formatted the same as scanner.Indent:
+// each element begins on a new line beginning with prefix followed by one or more copies of indent
+// according to the indentation nesting, and comments on the same line are written after a tab.
+// Leading, Dangling and Trailing comments are written on their own lines.
+// As scanner.Indent, the text appended does not begin with the prefix nor any indentation.
+func Print(dst *bytes.Buffer, n *Node, prefix, indent string) {
+	PrintWithOptions(dst, n, PrintOptions{Prefix: prefix, Indent: indent})
+}
+
+// PrintOptions controls the output of PrintWithOptions.
+type PrintOptions struct {
+	// Prefix and Indent are the same as the prefix and indent of Print.
+	Prefix, Indent string
+	// AlignComments aligns the comments after values on consecutive lines to the same column,
+	// instead of writing them after a tab.
+	AlignComments bool
+	// CommentSyntax is the syntax of comments: "#" (the default), "//" or "/* */".
+	// Note that "//" and "/* */" comments are accepted by the scanner only in lenient mode.
+	CommentSyntax string
+}
+
+// PrintWithOptions is like Print but uses opts to control the output.
+func PrintWithOptions(dst *bytes.Buffer, n *Node, opts PrintOptions) {
+	p := printer{opts: opts}
+	for _, c := range n.Leading {
+		p.ownLineComment(c, 0)
+	}
+	p.node(n, 0)
+	if n.IsScalar() {
+		p.comment(n.Comment)
+	} else {
+		p.comment(n.EndComment)
+	}
+	for _, c := range n.Trailing {
+		p.newline(0)
+		p.ownLineComment(c, -1)
+	}
+	p.newline(-1)
+	p.flush(dst)
+}
+
+type printer struct {
+	opts  PrintOptions
+	lines []line
+	code  bytes.Buffer // code of the current line
+	c     string       // comment of the current line
+}
+
+// line is a line of output, with the comment after its code.
+type line struct {
+	code, comment string
+}
+
+func (p *printer) node(n *Node, depth int) {
+	if n.IsScalar() {
+		p.code.WriteString(n.Value)
+		return
+	}
+	open, end := byte('{'), byte('}')
+	if n.Kind == Array {
+		open, end = '[', ']'
+	}
+	p.code.WriteByte(open)
+	p.comment(n.Comment)
+	for i, child := range n.Children {
+		p.newline(depth + 1)
+		for _, c := range child.Leading {
+			p.ownLineComment(c, depth+1)
+		}
+		if n.Kind == Object {
+			p.code.WriteString(quote(child.Key))
+			p.code.WriteString(": ")
+		}
+		p.node(child, depth+1)
+		if i < len(n.Children)-1 {
+			p.code.WriteByte(',')
+		}
+		if child.IsScalar() {
+			p.comment(child.Comment)
+		} else {
+			p.comment(child.EndComment)
+		}
+	}
+	for _, c := range n.Dangling {
+		p.newline(depth + 1)
+		p.ownLineComment(c, -1)
+	}
+	p.newline(depth)
+	p.code.WriteByte(end)
+}
+
+// comment sets the comment after the code of the current line.
+func (p *printer) comment(c string) {
+	if c != "" {
+		p.c = p.commentText(c)
+	}
+}
+
+// ownLineComment writes a comment on its own line, and begins the next line at depth,
+// or no line if depth is negative.
+func (p *printer) ownLineComment(c string, depth int) {
+	p.code.WriteString(p.commentText(c))
+	if depth >= 0 {
+		p.newline(depth)
+	}
+}
+
+func (p *printer) commentText(c string) string {
+	switch p.opts.CommentSyntax {
+	case "//":
+		return "// " + c
+	case "/* */":
+		return "/* " + strings.Replace(c, "*/", "* /", -1) + " */"
+	}
+	return "# " + c
+}
+
+// newline ends the current line, and begins the next line at depth, or no line if depth is negative.
+func (p *printer) newline(depth int) {
+	p.lines = append(p.lines, line{code: p.code.String(), comment: p.c})
+	p.code.Reset()
+	p.c = ""
+	if depth < 0 {
+		return
+	}
+	p.code.WriteString(p.opts.Prefix)
+	for i := 0; i < depth; i++ {
+		p.code.WriteString(p.opts.Indent)
+	}
+}
+
+func (p *printer) flush(dst *bytes.Buffer) {
+	for i := 0; i < len(p.lines); i++ {
+		if !p.opts.AlignComments || p.lines[i].comment == "" {
+			p.writeLine(dst, i, "\t ")
+			continue
+		}
+		// align the comments on consecutive lines.
+		j, maxWidth := i, 0
+		for ; j < len(p.lines) && p.lines[j].comment != ""; j++ {
+			if w := width.String(p.lines[j].code); w > maxWidth {
+				maxWidth = w
+			}
+		}
+		for ; i < j; i++ {
+			p.writeLine(dst, i, strings.Repeat(" ", maxWidth-width.String(p.lines[i].code)+2))
+		}
+		i--
+	}
+}
+
+func (p *printer) writeLine(dst *bytes.Buffer, i int, space string) {
+	if i > 0 {
+		dst.WriteByte('\n')
+	}
+	dst.WriteString(p.lines[i].code)
+	if c := p.lines[i].comment; c != "" {
+		dst.WriteString(space)
+		dst.WriteString(c)
+	}
+}
+
+// quote returns s as a JSON string, without escaping HTML characters.
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
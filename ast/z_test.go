@@ -0,0 +1,35 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+)
+
+func ExampleParse() {
+	n, err := Parse([]byte(`# 用户
+{ # 基本信息
+  "name": "Tom", # 姓名
+  # 以岁为单位
+  "age": 18
+  # 其他字段待定
+}`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	n.Children = append(n.Children, &Node{Kind: String, Key: "email", Value: `"tom@example.com"`, Comment: "邮箱"})
+
+	var buf bytes.Buffer
+	Print(&buf, n, "", "  ")
+	fmt.Println(buf.String())
+
+	// Output:
+	// # 用户
+	// {	 # 基本信息
+	//   "name": "Tom",	 # 姓名
+	//   # 以岁为单位
+	//   "age": 18,
+	//   "email": "tom@example.com"	 # 邮箱
+	//   # 其他字段待定
+	// }
+}
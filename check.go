@@ -0,0 +1,21 @@
+package jsondoc
+
+import "github.com/lovego/jsondoc/decoder"
+
+// A Problem is a difference between a jsondoc document and the document its Go type would generate.
+type Problem = decoder.Problem
+
+// Check compares the jsondoc text doc, like an example pasted in documentation,
+// with the document the type of v would generate. It reports unknown keys, missing fields,
+// kinds of values that don't match the types, and comments that differ from the "c" tags.
+// Keys are matched to struct fields by the same naming rules as MarshalIndent.
+func Check(doc []byte, v interface{}) []Problem {
+	return CheckWithOptions(doc, v, Options{})
+}
+
+// CheckWithOptions is like Check, but compares doc with the document MarshalIndentWithOptions would generate
+// with opts, like a document filtered by APIVersion or Views. Comments are not compared
+// with OmitComments or PathComments.
+func CheckWithOptions(doc []byte, v interface{}, opts Options) []Problem {
+	return decoder.CheckWithOptions(doc, v, opts.encoderOptions())
+}
@@ -0,0 +1,216 @@
+package decoder
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lovego/jsondoc/ast"
+	"github.com/lovego/jsondoc/encoder/funcs"
+	"github.com/lovego/jsondoc/encoder/types"
+)
+
+// A Problem is a difference between a jsondoc document and the document its Go type would generate.
+type Problem struct {
+	// Path is the path of the value, like "data.items[].sku", or "" for the top-level value.
+	Path string
+	Msg  string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Msg
+	}
+	return p.Path + ": " + p.Msg
+}
+
+// Check parses the jsondoc text data, and compares it with the document the type of v would generate,
+// reporting unknown keys, missing fields, kinds of values that don't match the types,
+// and comments that differ from the comments of struct fields.
+// Fields with the "omitempty" option may be missing, and values of types implementing json.Marshaler are not checked.
+// If data is not valid jsondoc text, the syntax error is the only problem.
+func Check(data []byte, v interface{}) []Problem {
+	return CheckWithOptions(data, v, types.Options{})
+}
+
+// CheckWithOptions is like Check, but compares data with the document generated with opts,
+// so that fields filtered out by APIVersion, Views or OmitDeprecated are unknown keys,
+// and comments are compared as affected by OmitVersionNotes.
+// Comments are not compared with OmitComments or PathComments,
+// and keys of pointer fields are without the "*" prefix with OmitPointerMark.
+func CheckWithOptions(data []byte, v interface{}, opts types.Options) []Problem {
+	n, err := ast.Parse(data)
+	if err != nil {
+		return []Problem{{Msg: err.Error()}}
+	}
+	c := checker{opts: opts}
+	c.value(n, reflect.TypeOf(v), "")
+	return c.problems
+}
+
+type checker struct {
+	opts     types.Options
+	problems []Problem
+}
+
+func (c *checker) report(path, msg string) {
+	c.problems = append(c.problems, Problem{Path: path, Msg: msg})
+}
+
+func (c *checker) value(n *ast.Node, t reflect.Type, path string) {
+	if t == nil {
+		return
+	}
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t, nullable = t.Elem(), true
+	}
+	switch {
+	case funcs.IsMarshaler(t):
+		return
+	case funcs.IsTextMarshaler(t):
+		c.kind(n, t, path, ast.String, nullable)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+	case reflect.Struct:
+		if c.kind(n, t, path, ast.Object, nullable) {
+			c.structFields(n, t, path)
+		}
+	case reflect.Map:
+		if c.kind(n, t, path, ast.Object, true) {
+			for _, child := range n.Children {
+				c.value(child, t.Elem(), childPath(path, child.Key))
+			}
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !funcs.IsMarshaler(t.Elem()) && !funcs.IsTextMarshaler(t.Elem()) {
+			c.kind(n, t, path, ast.String, true) // base64 encoded
+			return
+		}
+		fallthrough
+	case reflect.Array:
+		if c.kind(n, t, path, ast.Array, nullable || t.Kind() == reflect.Slice) {
+			for _, child := range n.Children {
+				c.value(child, t.Elem(), path+"[]")
+			}
+		}
+	case reflect.String:
+		if t == numberType && n.Kind == ast.Number {
+			return
+		}
+		c.kind(n, t, path, ast.String, nullable)
+	case reflect.Bool:
+		c.kind(n, t, path, ast.Bool, nullable)
+	default:
+		c.kind(n, t, path, ast.Number, nullable)
+	}
+}
+
+// kind reports a problem if n is not of kind, or null when nullable, and returns whether n is of kind.
+func (c *checker) kind(n *ast.Node, t reflect.Type, path string, kind ast.Kind, nullable bool) bool {
+	if n.Kind == kind {
+		return true
+	}
+	if n.Kind != ast.Null || !nullable {
+		c.report(path, "got "+n.Kind.String()+", want "+kind.String()+" ("+t.String()+")")
+	}
+	return false
+}
+
+func (c *checker) structFields(n *ast.Node, t reflect.Type, path string) {
+	fields := funcs.TypeFields(t, c.opts)
+	found := make([]bool, len(fields))
+	for _, child := range n.Children {
+		i := fieldIndex(fields, child.Key)
+		if i < 0 {
+			c.report(childPath(path, child.Key), "unknown key"+c.suggestKey(fields, child.Key))
+			continue
+		}
+		f := &fields[i]
+		found[i] = true
+		if key := c.fieldKey(f); child.Key != key {
+			c.report(childPath(path, child.Key), "key should be "+quote(key))
+		}
+		fieldPath := childPath(path, f.Name)
+		if !c.opts.OmitComments && !c.opts.PathComments {
+			c.comment(child, f, fieldPath)
+		}
+		ft := f.Type
+		if f.Pointer {
+			ft = reflect.PtrTo(ft)
+		}
+		if f.Quoted {
+			c.kind(child, ft, fieldPath, ast.String, f.Pointer)
+			continue
+		}
+		c.value(child, ft, fieldPath)
+	}
+	for i := range fields {
+		if !found[i] && !fields[i].OmitEmpty {
+			c.report(childPath(path, fields[i].Name), "missing field")
+		}
+	}
+}
+
+// comment reports a problem if the comment of a field on the same line as n differs from f.Comment.
+// Comments on their own lines before n are free text, and not compared.
+func (c *checker) comment(n *ast.Node, f *funcs.Field, path string) {
+	if f.Comment == "" {
+		return
+	}
+	// the encoder writes the comment of an object or array after its opening bracket,
+	// but it may also be after the closing bracket in a document written by hand.
+	comment := n.Comment
+	if comment == "" {
+		comment = n.EndComment
+	}
+	if comment == "" {
+		c.report(path, "missing comment "+quote(f.Comment))
+	} else if comment != f.Comment {
+		c.report(path, "comment "+quote(comment)+", want "+quote(f.Comment))
+	}
+}
+
+// fieldIndex returns the index of the field of key, with or without the "*" prefix of pointer fields.
+func fieldIndex(fields []funcs.Field, key string) int {
+	name := strings.TrimPrefix(key, "*")
+	for i := range fields {
+		if fields[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// suggestKey returns a suggestion for an unknown key, if a field matches it case-insensitively.
+func (c *checker) suggestKey(fields []funcs.Field, key string) string {
+	name := strings.TrimPrefix(key, "*")
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, name) {
+			return ", did you mean " + quote(c.fieldKey(&fields[i]))
+		}
+	}
+	return ""
+}
+
+// fieldKey returns the key of a field in jsondoc, with the "*" prefix for pointer fields unless OmitPointerMark.
+func (c *checker) fieldKey(f *funcs.Field) string {
+	if f.Pointer && !c.opts.OmitPointerMark {
+		return "*" + f.Name
+	}
+	return f.Name
+}
+
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
@@ -0,0 +1,378 @@
+// Package decoder decodes jsondoc text into Go values.
+package decoder
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lovego/jsondoc/ast"
+	"github.com/lovego/jsondoc/encoder/funcs"
+	"github.com/lovego/jsondoc/encoder/types"
+)
+
+var numberType = reflect.TypeOf(json.Number(""))
+
+// Unmarshal parses the jsondoc text data, comments included, and stores the result in the value pointed to by v.
+// Struct fields are matched by the same names as the encoder, the "*" prefix of pointer fields is optional,
+// and like json.Unmarshal, keys are matched case-insensitively if there is no exact match.
+// Values of types implementing json.Unmarshaler or encoding.TextUnmarshaler are decoded by them.
+// If a value is not appropriate for a Go type, Unmarshal continues decoding the remaining values,
+// and returns the first json.UnmarshalTypeError encountered.
+func Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	n, err := ast.Parse(data)
+	if err != nil {
+		return err
+	}
+	var d decodeState
+	d.value(n, rv, "")
+	return d.err
+}
+
+type decodeState struct {
+	structName string // name of the innermost struct type being decoded, for errors
+	err        error
+}
+
+func (d *decodeState) saveError(err error) {
+	if d.err == nil {
+		if e, ok := err.(*json.UnmarshalTypeError); ok && e.Field != "" {
+			e.Struct = d.structName
+		}
+		d.err = err
+	}
+}
+
+func (d *decodeState) typeError(n *ast.Node, t reflect.Type, path string) {
+	d.saveError(&json.UnmarshalTypeError{Value: n.Kind.String(), Type: t, Field: path})
+}
+
+// value decodes node n into v, path is the path of struct fields for errors.
+func (d *decodeState) value(n *ast.Node, v reflect.Value, path string) {
+	t := v.Type() // the type of v before indirect, for errors.
+	u, tu, v := indirect(v, n.Kind == ast.Null)
+	if u != nil {
+		b, _ := n.MarshalJSON()
+		if err := u.UnmarshalJSON(b); err != nil {
+			d.saveError(err)
+		}
+		return
+	}
+	if n.Kind == ast.Null {
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return
+	}
+	if tu != nil {
+		if n.Kind != ast.String {
+			d.typeError(n, t, path)
+			return
+		}
+		if err := tu.UnmarshalText([]byte(n.Text())); err != nil {
+			d.saveError(err)
+		}
+		return
+	}
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		v.Set(reflect.ValueOf(generic(n)))
+		return
+	}
+
+	switch n.Kind {
+	case ast.Object:
+		d.object(n, v, path)
+	case ast.Array:
+		d.array(n, v, path)
+	case ast.String:
+		d.string(n, v, path)
+	case ast.Number:
+		d.number(n, v, path)
+	case ast.Bool:
+		if v.Kind() != reflect.Bool {
+			d.typeError(n, v.Type(), path)
+			return
+		}
+		v.SetBool(n.Value == "true")
+	}
+}
+
+func (d *decodeState) object(n *ast.Node, v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Struct:
+		d.structFields(n, v, path)
+	case reflect.Map:
+		d.mapEntries(n, v, path)
+	default:
+		d.typeError(n, v.Type(), path)
+	}
+}
+
+func (d *decodeState) structFields(n *ast.Node, v reflect.Value, path string) {
+	fields := funcs.TypeFields(v.Type(), types.Options{})
+	defer func(name string) { d.structName = name }(d.structName)
+	d.structName = v.Type().Name()
+	for _, child := range n.Children {
+		f := findField(fields, child.Key)
+		if f == nil {
+			continue
+		}
+		fv := v
+		for _, i := range f.Index {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					if !fv.CanSet() {
+						// embedded pointer to unexported struct type.
+						fv = reflect.Value{}
+						break
+					}
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			fv = fv.Field(i)
+		}
+		if !fv.IsValid() {
+			continue
+		}
+		fieldPath := f.Name
+		if path != "" {
+			fieldPath = path + "." + f.Name
+		}
+		if f.Quoted && child.Kind == ast.String {
+			quoted, err := ast.Parse([]byte(child.Text()))
+			if err != nil || !quoted.IsScalar() {
+				d.saveError(&json.UnmarshalTypeError{Value: "string", Type: fv.Type(), Field: fieldPath})
+				continue
+			}
+			child = quoted
+		}
+		d.value(child, fv, fieldPath)
+	}
+}
+
+// findField returns the field of key, which may have the "*" prefix of pointer fields.
+func findField(fields []funcs.Field, key string) *funcs.Field {
+	var fold *funcs.Field
+	for i := range fields {
+		f := &fields[i]
+		name := key
+		if f.Pointer && strings.HasPrefix(name, "*") {
+			name = name[1:]
+		}
+		if f.Name == name {
+			return f
+		}
+		if fold == nil && strings.EqualFold(f.Name, name) {
+			fold = f
+		}
+	}
+	return fold
+}
+
+func (d *decodeState) mapEntries(n *ast.Node, v reflect.Value, path string) {
+	t := v.Type()
+	keyType := t.Key()
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	for _, child := range n.Children {
+		key := reflect.New(keyType).Elem()
+		if tu, ok := key.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := tu.UnmarshalText([]byte(child.Key)); err != nil {
+				d.saveError(err)
+				continue
+			}
+		} else {
+			switch keyType.Kind() {
+			case reflect.String:
+				key.SetString(child.Key)
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				i, err := strconv.ParseInt(child.Key, 10, 64)
+				if err != nil || key.OverflowInt(i) {
+					d.saveError(&json.UnmarshalTypeError{Value: "number " + child.Key, Type: keyType, Field: path})
+					continue
+				}
+				key.SetInt(i)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				u, err := strconv.ParseUint(child.Key, 10, 64)
+				if err != nil || key.OverflowUint(u) {
+					d.saveError(&json.UnmarshalTypeError{Value: "number " + child.Key, Type: keyType, Field: path})
+					continue
+				}
+				key.SetUint(u)
+			default:
+				d.typeError(n, t, path)
+				return
+			}
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		d.value(child, elem, path)
+		v.SetMapIndex(key, elem)
+	}
+}
+
+func (d *decodeState) array(n *ast.Node, v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() || v.Cap() < len(n.Children) {
+			v.Set(reflect.MakeSlice(v.Type(), len(n.Children), len(n.Children)))
+		} else {
+			v.SetLen(len(n.Children))
+		}
+	case reflect.Array:
+	default:
+		d.typeError(n, v.Type(), path)
+		return
+	}
+	for i, child := range n.Children {
+		if i >= v.Len() {
+			break
+		}
+		d.value(child, v.Index(i), path)
+	}
+	if v.Kind() == reflect.Array {
+		for i := len(n.Children); i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		}
+	}
+}
+
+func (d *decodeState) string(n *ast.Node, v reflect.Value, path string) {
+	s := n.Text()
+	switch {
+	case v.Kind() == reflect.String && v.Type() == numberType:
+		if !isNumber(s) {
+			d.saveError(&json.UnmarshalTypeError{Value: "string", Type: v.Type(), Field: path})
+			return
+		}
+		v.SetString(s)
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			d.saveError(err)
+			return
+		}
+		v.SetBytes(b)
+	default:
+		d.typeError(n, v.Type(), path)
+	}
+}
+
+func (d *decodeState) number(n *ast.Node, v reflect.Value, path string) {
+	s := n.Value
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || v.OverflowInt(i) {
+			d.saveError(&json.UnmarshalTypeError{Value: "number " + s, Type: v.Type(), Field: path})
+			return
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || v.OverflowUint(u) {
+			d.saveError(&json.UnmarshalTypeError{Value: "number " + s, Type: v.Type(), Field: path})
+			return
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil || v.OverflowFloat(f) {
+			d.saveError(&json.UnmarshalTypeError{Value: "number " + s, Type: v.Type(), Field: path})
+			return
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		if v.Type() != numberType {
+			d.typeError(n, v.Type(), path)
+			return
+		}
+		v.SetString(s)
+	default:
+		d.typeError(n, v.Type(), path)
+	}
+}
+
+// indirect walks down v allocating pointers as needed, until it gets to a non-pointer.
+// If it encounters an Unmarshaler, indirect stops and returns that.
+// If decodingNull is true, indirect stops at the first settable pointer so it can be set to nil.
+func indirect(v reflect.Value, decodingNull bool) (json.Unmarshaler, encoding.TextUnmarshaler, reflect.Value) {
+	// If v is a named type and is addressable, start with its address,
+	// so that if the type has pointer methods, we find them.
+	if v.Kind() != reflect.Ptr && v.Type().Name() != "" && v.CanAddr() {
+		v = v.Addr()
+	}
+	for {
+		// Load value from interface, but only if the result will be usefully addressable.
+		if v.Kind() == reflect.Interface && !v.IsNil() {
+			if e := v.Elem(); e.Kind() == reflect.Ptr && !e.IsNil() {
+				v = e
+				continue
+			}
+		}
+		if v.Kind() != reflect.Ptr {
+			break
+		}
+		if decodingNull && v.CanSet() {
+			break
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if v.Type().NumMethod() > 0 && v.CanInterface() {
+			if u, ok := v.Interface().(json.Unmarshaler); ok {
+				return u, nil, reflect.Value{}
+			}
+			if u, ok := v.Interface().(encoding.TextUnmarshaler); ok {
+				return nil, u, reflect.Value{}
+			}
+		}
+		v = v.Elem()
+	}
+	return nil, nil, v
+}
+
+// generic returns the value of n as map[string]interface{}, []interface{}, string, float64, bool or nil.
+func generic(n *ast.Node) interface{} {
+	switch n.Kind {
+	case ast.Object:
+		m := make(map[string]interface{}, len(n.Children))
+		for _, child := range n.Children {
+			m[child.Key] = generic(child)
+		}
+		return m
+	case ast.Array:
+		a := make([]interface{}, len(n.Children))
+		for i, child := range n.Children {
+			a[i] = generic(child)
+		}
+		return a
+	case ast.String:
+		return n.Text()
+	case ast.Number:
+		f, _ := strconv.ParseFloat(n.Value, 64)
+		return f
+	case ast.Bool:
+		return n.Value == "true"
+	}
+	return nil
+}
+
+func isNumber(s string) bool {
+	var f json.Number
+	return json.Unmarshal([]byte(s), &f) == nil
+}
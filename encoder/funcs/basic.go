@@ -21,7 +21,7 @@ func boolEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 	if opts.Quoted {
 		buf.WriteByte('"')
 	}
-	if v.Bool() {
+	if v.Bool() && !opts.Masked() {
 		buf.WriteString("true")
 	} else {
 		buf.WriteString("false")
@@ -32,6 +32,10 @@ func boolEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 }
 
 func intEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
+	if opts.Masked() {
+		writeMaskedNumber(buf, opts)
+		return
+	}
 	b := strconv.AppendInt(buf.Scratch[:0], v.Int(), 10)
 	if opts.Quoted {
 		buf.WriteByte('"')
@@ -43,6 +47,10 @@ func intEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 }
 
 func uintEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
+	if opts.Masked() {
+		writeMaskedNumber(buf, opts)
+		return
+	}
 	b := strconv.AppendUint(buf.Scratch[:0], v.Uint(), 10)
 	if opts.Quoted {
 		buf.WriteByte('"')
@@ -55,6 +63,10 @@ func uintEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 
 func stringEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 	if v.Type() == numberType {
+		if opts.Masked() {
+			buf.WriteByte('0')
+			return
+		}
 		numStr := v.String()
 		// In Go1.5 the empty string encodes to "0", while this is not a valid number literal
 		// we keep compatibility so check validity after this.
@@ -67,18 +79,26 @@ func stringEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 		buf.WriteString(numStr)
 		return
 	}
+	s := v.String()
+	if opts.Masked() {
+		s = maskString(s)
+	}
 	if opts.Quoted {
 		b := new(bytes.Buffer)
-		encodeString(b, v.String(), opts.EscapeHTML)
+		encodeString(b, s, opts.EscapeHTML)
 		encodeString(&buf.Buffer, b.String(), opts.EscapeHTML)
 	} else {
-		encodeString(&buf.Buffer, v.String(), opts.EscapeHTML)
+		encodeString(&buf.Buffer, s, opts.EscapeHTML)
 	}
 }
 
 type floatEncoder int // number of bits
 
 func (bits floatEncoder) encode(buf *types.Buffer, v reflect.Value, opts types.Options) {
+	if opts.Masked() {
+		writeMaskedNumber(buf, opts)
+		return
+	}
 	f := v.Float()
 	if math.IsInf(f, 0) || math.IsNaN(f) {
 		raiseError(&UnsupportedValueError{v, strconv.FormatFloat(f, 'g', -1, int(bits))})
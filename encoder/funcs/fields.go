@@ -0,0 +1,67 @@
+package funcs
+
+import (
+	"reflect"
+
+	"github.com/lovego/jsondoc/encoder/types"
+)
+
+// A Field describes a struct field resolved by the same rules as the encoder,
+// for generators other than the JSON encoder.
+type Field struct {
+	// Name is the key in JSON, without the "*" prefix of pointer fields.
+	Name string
+	// Index is the index sequence for reflect.Type.FieldByIndex.
+	Index []int
+	// Type is the field type, with unnamed pointer type followed.
+	Type reflect.Type
+	// Pointer reports whether Type is followed from an unnamed pointer type.
+	Pointer    bool
+	OmitEmpty  bool
+	Quoted     bool
+	Sensitive  bool
+	Deprecated bool
+	// Comment is the comment text, including the deprecation notice and api version.
+	Comment string
+	// Description is the comment text without the deprecation notice.
+	Description string
+	// Deprecation is the reason of deprecation, like "use Name instead", if Deprecated.
+	Deprecation string
+	// Tag is the struct field tag, for generators to read their own tags.
+	Tag reflect.StructTag
+}
+
+// TypeFields returns the fields of struct type t visible with opts, in the order of encoding.
+func TypeFields(t reflect.Type, opts types.Options) []Field {
+	fields := cachedVisibleFields(t, &opts)
+	result := make([]Field, 0, len(fields))
+	for i := range fields {
+		f := &fields[i]
+		result = append(result, Field{
+			Name:        f.key(),
+			Index:       f.index,
+			Type:        f.typ,
+			Pointer:     f.pointer,
+			OmitEmpty:   f.omitEmpty,
+			Quoted:      f.quoted,
+			Sensitive:   f.sensitive,
+			Deprecated:  f.deprecated,
+			Comment:     f.commentText,
+			Description: f.description,
+			Deprecation: f.deprecation,
+			Tag:         f.structTag,
+		})
+	}
+	return result
+}
+
+// IsMarshaler reports whether values of type t are encoded by their MarshalJSON method.
+func IsMarshaler(t reflect.Type) bool {
+	return t.Implements(marshalerType) || t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(marshalerType)
+}
+
+// IsTextMarshaler reports whether values of type t are encoded as strings by their MarshalText method.
+func IsTextMarshaler(t reflect.Type) bool {
+	return !IsMarshaler(t) &&
+		(t.Implements(textMarshalerType) || t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(textMarshalerType))
+}
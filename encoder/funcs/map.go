@@ -56,14 +56,28 @@ func (me mapEncoder) encode(buf *types.Buffer, v reflect.Value, opts types.Optio
 	}
 	sort.Slice(sv, func(i, j int) bool { return sv[i].s < sv[j].s })
 
+	if opts.PathComments {
+		opts.AppendPath(types.MapElemPath)
+	}
+	lastElemOpts := types.Options{}
 	for i, kv := range sv {
 		if i > 0 {
 			buf.WriteByte(',')
+			lastElemOpts.WriteCommentIfPresent(buf)
 		}
 		encodeString(&buf.Buffer, kv.s, opts.EscapeHTML)
 		buf.WriteByte(':')
-		me.elemEnc(buf, v.MapIndex(kv.v), opts)
+		elemOpts := opts
+		if opts.PathComments {
+			setPathComment(&elemOpts, "")
+		}
+		if opts.IsSensitiveKey(kv.s) {
+			elemOpts.SetMasked()
+		}
+		me.elemEnc(buf, v.MapIndex(kv.v), elemOpts)
+		lastElemOpts = elemOpts
 	}
+	lastElemOpts.WriteCommentIfPresent(buf)
 	buf.WriteByte('}')
 }
 
@@ -43,16 +43,27 @@ func marshalerEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 		return
 	}
 	b, err := m.MarshalJSON()
+	if err == nil && opts.Masked() {
+		b, err = maskJSON(b)
+	}
 	if err == nil {
 		// copy JSON into types.Buffer, checking validity.
-		err = scanner.Compact(&buf.Buffer, b, opts.EscapeHTML)
+		err = scanner.CompactComments(&buf.Buffer, b, opts.EscapeHTML, commentMode(opts))
 	}
 	if err != nil {
 		raiseError(&MarshalerError{v.Type(), err})
 	}
 }
 
-func addrMarshalerEncoder(buf *types.Buffer, v reflect.Value, _ types.Options) {
+// commentMode returns how the comments in the output of MarshalJSON are copied.
+func commentMode(opts types.Options) scanner.CommentMode {
+	if opts.OmitComments {
+		return scanner.DropComments
+	}
+	return scanner.KeepComments
+}
+
+func addrMarshalerEncoder(buf *types.Buffer, v reflect.Value, opts types.Options) {
 	va := v.Addr()
 	if va.IsNil() {
 		buf.WriteString("null")
@@ -60,9 +71,12 @@ func addrMarshalerEncoder(buf *types.Buffer, v reflect.Value, _ types.Options) {
 	}
 	m := va.Interface().(Marshaler)
 	b, err := m.MarshalJSON()
+	if err == nil && opts.Masked() {
+		b, err = maskJSON(b)
+	}
 	if err == nil {
 		// copy JSON into types.Buffer, checking validity.
-		err = scanner.Compact(&buf.Buffer, b, true)
+		err = scanner.CompactComments(&buf.Buffer, b, true, commentMode(opts))
 	}
 	if err != nil {
 		raiseError(&MarshalerError{v.Type(), err})
@@ -78,6 +92,9 @@ func textMarshalerEncoder(buf *types.Buffer, v reflect.Value, opts types.Options
 	if err != nil {
 		raiseError(&MarshalerError{v.Type(), err})
 	}
+	if opts.Masked() {
+		b = []byte(maskString(string(b)))
+	}
 	encodeStringBytes(&buf.Buffer, b, opts.EscapeHTML)
 }
 
@@ -92,6 +109,9 @@ func addrTextMarshalerEncoder(buf *types.Buffer, v reflect.Value, opts types.Opt
 	if err != nil {
 		raiseError(&MarshalerError{v.Type(), err})
 	}
+	if opts.Masked() {
+		b = []byte(maskString(string(b)))
+	}
 	encodeStringBytes(&buf.Buffer, b, opts.EscapeHTML)
 }
 
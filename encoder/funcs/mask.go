@@ -0,0 +1,75 @@
+package funcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/lovego/jsondoc/encoder/types"
+	"github.com/lovego/jsondoc/scanner"
+)
+
+const mask = "***"
+
+// maskString masks s. Number-like strings such as phone numbers and ID numbers
+// keep their first 3 and last 4 characters, other strings are replaced entirely.
+func maskString(s string) string {
+	if len(s) < 8 || !isNumberLike(s) {
+		return mask
+	}
+	return s[:3] + strings.Repeat("*", len(s)-7) + s[len(s)-4:]
+}
+
+// isNumberLike reports whether s consists of digits, except an optional leading "+"
+// and an optional trailing "X" or "x" (used by check digits).
+func isNumberLike(s string) bool {
+	s = strings.TrimPrefix(s, "+")
+	s = strings.TrimRight(s, "Xx")
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func writeMaskedNumber(buf *types.Buffer, opts types.Options) {
+	if opts.Quoted {
+		buf.WriteString(`"0"`)
+	} else {
+		buf.WriteByte('0')
+	}
+}
+
+// maskJSON masks the JSON value produced by a MarshalJSON method,
+// keeping the type of the value. Comments in b are dropped.
+func maskJSON(b []byte) ([]byte, error) {
+	// strip comments before checking the type, and check validity meanwhile,
+	// so that invalid output is still reported.
+	var stripped bytes.Buffer
+	if err := scanner.StripComments(&stripped, b); err != nil {
+		return nil, err
+	}
+	b = bytes.TrimSpace(stripped.Bytes())
+	switch b[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, err
+		}
+		return json.Marshal(maskString(s))
+	case '{':
+		return []byte("{}"), nil
+	case '[':
+		return []byte("[]"), nil
+	case 't', 'f':
+		return []byte("false"), nil
+	case 'n':
+		return []byte("null"), nil
+	default:
+		return []byte("0"), nil
+	}
+}
@@ -56,20 +56,35 @@ func (ae arrayEncoder) encode(buf *types.Buffer, v reflect.Value, opts types.Opt
 	if n > 0 {
 		opts.WriteCommentIfPresent(buf)
 	}
+	if opts.PathComments {
+		opts.AppendPath(types.ArrayElemPath)
+	}
+	lastElemOpts := types.Options{}
 	for i := 0; i < n; i++ {
 		if i > 0 {
 			buf.WriteByte(',')
+			lastElemOpts.WriteCommentIfPresent(buf)
+		}
+		elemOpts := opts
+		if opts.PathComments {
+			setPathComment(&elemOpts, "")
 		}
-		ae.elemEnc(buf, v.Index(i), opts)
+		ae.elemEnc(buf, v.Index(i), elemOpts)
+		lastElemOpts = elemOpts
 	}
+	lastElemOpts.WriteCommentIfPresent(buf)
 	buf.WriteByte(']')
 }
 
-func encodeByteSlice(buf *types.Buffer, v reflect.Value, _ types.Options) {
+func encodeByteSlice(buf *types.Buffer, v reflect.Value, opts types.Options) {
 	if v.IsNil() {
 		buf.WriteString("null")
 		return
 	}
+	if opts.Masked() {
+		encodeString(&buf.Buffer, maskString(""), opts.EscapeHTML)
+		return
+	}
 	s := v.Bytes()
 	buf.WriteByte('"')
 	encodedLen := base64.StdEncoding.EncodedLen(len(s))
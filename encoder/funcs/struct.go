@@ -2,31 +2,29 @@ package funcs
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/lovego/jsondoc/encoder/types"
 )
 
 func newStructEncoder(t reflect.Type) encoderFunc {
-	se := structEncoder{fields: cachedTypeFields(t)}
+	se := structEncoder{typ: t}
 	return se.encode
 }
 
 type structEncoder struct {
-	fields []field
+	typ reflect.Type
 }
 
 func (se structEncoder) encode(buf *types.Buffer, v reflect.Value, opts types.Options) {
 	buf.WriteByte('{')
-	if len(se.fields) > 0 {
-		opts.WriteCommentIfPresent(buf)
-	}
 	needComma := false
 	lastFieldOpts := types.Options{}
+	fields := cachedVisibleFields(se.typ, &opts)
 fieldLoop:
-	for i := range se.fields {
-		f := &se.fields[i]
-
+	for i := range fields {
+		f := &fields[i]
 		nextLayerOpts := opts // options for next layer
 
 		// Find the nested struct field by following f.index.
@@ -50,14 +48,29 @@ fieldLoop:
 		if needComma {
 			buf.WriteByte(',')
 			lastFieldOpts.WriteCommentIfPresent(buf)
+		} else {
+			// write the comment only if there are visible fields.
+			opts.WriteCommentIfPresent(buf)
 		}
+		name := f.nameNonEsc
 		if nextLayerOpts.EscapeHTML {
-			buf.WriteString(f.nameEscHTML)
-		} else {
-			buf.WriteString(f.nameNonEsc)
+			name = f.nameEscHTML
+		}
+		if f.pointer && opts.OmitPointerMark {
+			buf.WriteByte('"')
+			name = name[2:] // skip `"*`
 		}
+		buf.WriteString(name)
 		nextLayerOpts.Quoted = f.quoted
-		nextLayerOpts.SetComment(f.comment, f.commentHTML)
+		if f.sensitive || opts.IsSensitiveKey(f.key()) {
+			nextLayerOpts.SetMasked()
+		}
+		if opts.PathComments {
+			nextLayerOpts.AppendPath("." + f.key())
+			setPathComment(&nextLayerOpts, f.commentText)
+		} else {
+			nextLayerOpts.SetComment(f.comment, f.commentHTML)
+		}
 
 		f.encoder(buf, fv, nextLayerOpts)
 		needComma = true
@@ -77,3 +90,41 @@ func cachedTypeFields(t reflect.Type) []field {
 	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
 	return f.([]field)
 }
+
+// visibilityKey identifies a struct type with the options that affect its visible fields.
+type visibilityKey struct {
+	typ              reflect.Type
+	omitDeprecated   bool
+	apiVersion       string
+	views            string
+	omitVersionNotes bool
+}
+
+var visibleFieldCache sync.Map // map[visibilityKey][]field
+
+// cachedVisibleFields is like visibleFields but uses a cache to avoid repeated work.
+func cachedVisibleFields(t reflect.Type, opts *types.Options) []field {
+	key := visibilityKey{
+		typ:              t,
+		omitDeprecated:   opts.OmitDeprecated,
+		apiVersion:       opts.APIVersion,
+		views:            strings.Join(opts.Views, ","),
+		omitVersionNotes: opts.OmitVersionNotes,
+	}
+	if f, ok := visibleFieldCache.Load(key); ok {
+		return f.([]field)
+	}
+	f, _ := visibleFieldCache.LoadOrStore(key, visibleFields(cachedTypeFields(t), opts))
+	return f.([]field)
+}
+
+// setPathComment sets the comment of the value to comment with its JSON path appended.
+func setPathComment(opts *types.Options, comment string) {
+	if comment == "" {
+		comment = opts.Path()
+	} else {
+		comment += " (" + opts.Path() + ")"
+	}
+	comment = formatComment(comment)
+	opts.SetComment(comment, htmlEscape(comment))
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/lovego/jsondoc/encoder/types"
 	"github.com/lovego/struct_tag"
 )
 
@@ -20,18 +21,29 @@ type field struct {
 	nameEscHTML string // `"` + escapeHTML(name) + `":`
 
 	tag       bool
+	structTag reflect.StructTag
 	index     []int
 	typ       reflect.Type
+	pointer   bool // typ is followed from an unnamed pointer type, name is prefixed with "*".
 	omitEmpty bool
 	quoted    bool
+	sensitive bool // the value should be masked
 
 	encoder     encoderFunc
+	commentText string // comment without formatting
 	comment     string
 	commentHTML string
+
+	deprecated  bool     // marked by the "deprecated" tag or a "Deprecated:" comment prefix
+	deprecation string   // the reason of deprecation, like "use Name instead"
+	description string   // the comment text without the deprecation notice
+	since       string   // the "since" tag, the first api version that has this field
+	until       string   // the "until" tag, the first api version that doesn't have this field
+	views       []string // the "view" tag, the audiences this field is visible to
 }
 
-// typeFields returns a list of fields that JSON should recognize for the given type.
-// The algorithm is breadth-first search over the set of structs to include - the top struct
+// typeFields returns a list of fields that JSON may recognize for the given type, sorted by name.
+// Fields with the same name are resolved by visibleFields. The algorithm is breadth-first search over the set of structs to include - the top struct
 // and then any reachable anonymous structs.
 func typeFields(t reflect.Type) []field {
 	// Anonymous fields to explore at the current level and the next.
@@ -119,14 +131,30 @@ func typeFields(t reflect.Type) []field {
 					if name == "" {
 						name = sf.Name
 					}
+					description, deprecation, deprecated := getDeprecation(sf.Tag, getComment(sf.Tag))
+					comment := description
+					if deprecated {
+						comment = deprecationNotice(deprecation, description)
+					}
+					since, until := getVersions(sf.Tag)
 					field := field{
-						name:      point + name,
-						tag:       tagged,
-						index:     index,
-						typ:       ft,
-						omitEmpty: opts.Contains("omitempty"),
-						quoted:    quoted,
-						comment:   getComment(sf.Tag),
+						name:        point + name,
+						tag:         tagged,
+						structTag:   sf.Tag,
+						index:       index,
+						typ:         ft,
+						pointer:     point != "",
+						omitEmpty:   opts.Contains("omitempty"),
+						quoted:      quoted,
+						sensitive:   opts.Contains("sensitive"),
+						commentText: comment,
+						comment:     formatComment(comment),
+						deprecated:  deprecated,
+						deprecation: deprecation,
+						description: description,
+						since:       since,
+						until:       until,
+						views:       getViews(sf.Tag),
 					}
 					field.nameBytes = []byte(field.name)
 
@@ -138,9 +166,7 @@ func typeFields(t reflect.Type) []field {
 					field.nameEscHTML = buf.String()
 					field.nameNonEsc = `"` + field.name + `":`
 
-					buf.Reset()
-					json.HTMLEscape(&buf, []byte(field.comment))
-					field.commentHTML = buf.String()
+					field.commentHTML = htmlEscape(field.comment)
 
 					fields = append(fields, field)
 					if count[f.typ] > 1 {
@@ -179,13 +205,32 @@ func typeFields(t reflect.Type) []field {
 		return byIndex(x).Less(i, j)
 	})
 
+	for i := range fields {
+		f := &fields[i]
+		f.encoder = typeEncoder(typeByIndex(t, f.index))
+	}
+	return fields
+}
+
+// visibleFields returns the fields visible with opts, in the order of encoding.
+// The fields must be sorted as typeFields returns. Fields with the same name are resolved
+// after invisible ones are removed, so a field can be renamed across api versions or views.
+func visibleFields(fields []field, opts *types.Options) []field {
+	out := make([]field, 0, len(fields))
+	for i := range fields {
+		if fields[i].visible(opts) {
+			out = append(out, fields[i])
+		}
+	}
+	fields = out
+
 	// Delete all fields that are hidden by the Go rules for embedded fields,
 	// except that fields with JSON tags are promoted.
 
 	// The fields are sorted in primary order of name, secondary order
 	// of field index length. Loop over names; for each name, delete
 	// hidden fields by choosing the one dominant field that survives.
-	out := fields[:0]
+	out = fields[:0]
 	for advance, i := 0, 0; i < len(fields); i += advance {
 		// One iteration per name.
 		// Find the sequence of fields with the name of this first field.
@@ -202,6 +247,11 @@ func typeFields(t reflect.Type) []field {
 			continue
 		}
 		dominant, ok := dominantField(fields[i : i+advance])
+		if !ok && opts.APIVersion == `` {
+			// Without an api version, the fields removed in some version are superseded
+			// by the ones still present.
+			dominant, ok = dominantField(currentFields(fields[i : i+advance]))
+		}
 		if ok {
 			out = append(out, dominant)
 		}
@@ -210,13 +260,67 @@ func typeFields(t reflect.Type) []field {
 	fields = out
 	sort.Sort(byIndex(fields))
 
-	for i := range fields {
-		f := &fields[i]
-		f.encoder = typeEncoder(typeByIndex(t, f.index))
+	if !opts.OmitVersionNotes {
+		for i := range fields {
+			f := &fields[i]
+			if f.since != `` {
+				f.commentText = strings.TrimSpace(f.commentText + ` (since ` + f.since + `)`)
+				f.description = strings.TrimSpace(f.description + ` (since ` + f.since + `)`)
+				f.comment = formatComment(f.commentText)
+				f.commentHTML = htmlEscape(f.comment)
+			}
+		}
 	}
 	return fields
 }
 
+// currentFields returns the fields without an "until" tag.
+func currentFields(fields []field) []field {
+	var result []field
+	for _, f := range fields {
+		if f.until == `` {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// key returns the field name without the "*" prefix of pointer fields.
+func (f *field) key() string {
+	if f.pointer {
+		return f.name[1:]
+	}
+	return f.name
+}
+
+// visible reports whether the field should be encoded with opts.
+func (f *field) visible(opts *types.Options) bool {
+	if f.deprecated && opts.OmitDeprecated {
+		return false
+	}
+	if opts.APIVersion != `` {
+		if f.since != `` && compareVersions(opts.APIVersion, f.since) < 0 ||
+			f.until != `` && compareVersions(opts.APIVersion, f.until) >= 0 {
+			return false
+		}
+	}
+	if len(opts.Views) > 0 && len(f.views) > 0 && !intersects(f.views, opts.Views) {
+		return false
+	}
+	return true
+}
+
+func intersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // dominantField looks through the fields, all of which are known to
 // have the same name, to find the single field that dominates the
 // others using Go's embedding rules, modified by the presence of
@@ -227,6 +331,9 @@ func dominantField(fields []field) (field, bool) {
 	// The fields are sorted in increasing index-length order, then by presence of tag.
 	// That means that the first field is the dominant one. We need only check
 	// for error cases: two fields at top level, either both tagged or neither tagged.
+	if len(fields) == 0 {
+		return field{}, false
+	}
 	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tag == fields[1].tag {
 		return field{}, false
 	}
@@ -276,7 +383,66 @@ func getComment(tag reflect.StructTag) string {
 	}
 	if comment != `` {
 		comment = whitespaceRegexp.ReplaceAllString(comment, " ")
-		comment = " # " + comment + "\n"
 	}
 	return comment
 }
+
+// format comment to be written after the field value
+func formatComment(comment string) string {
+	if comment == `` {
+		return ``
+	}
+	return " # " + comment + "\n"
+}
+
+func htmlEscape(s string) string {
+	var buf bytes.Buffer
+	json.HTMLEscape(&buf, []byte(s))
+	return buf.String()
+}
+
+// extract the reason of deprecation from the "deprecated" tag or a "Deprecated:" prefix of comment,
+// and return the comment without it.
+func getDeprecation(tag reflect.StructTag, comment string) (string, string, bool) {
+	reason, ok := struct_tag.Lookup(string(tag), `deprecated`)
+	if ok {
+		reason = whitespaceRegexp.ReplaceAllString(strings.TrimSpace(reason), " ")
+	} else if strings.HasPrefix(comment, `Deprecated:`) {
+		reason, comment = strings.TrimSpace(strings.TrimPrefix(comment, `Deprecated:`)), ``
+	} else {
+		return comment, ``, false
+	}
+	return comment, reason, true
+}
+
+// return the comment with the deprecation notice of reason prepended.
+func deprecationNotice(reason, comment string) string {
+	notice := reason
+	if notice == `` {
+		notice = `[DEPRECATED]`
+	} else {
+		notice = `[DEPRECATED: ` + notice + `]`
+	}
+	if comment == `` {
+		return notice
+	}
+	return notice + ` ` + comment
+}
+
+// extract the api versions range of a field from the "since" and "until" tags.
+func getVersions(tag reflect.StructTag) (since, until string) {
+	tagStr := string(tag)
+	since = strings.TrimSpace(struct_tag.Get(tagStr, `since`))
+	until = strings.TrimSpace(struct_tag.Get(tagStr, `until`))
+	return
+}
+
+// extract the audiences a field is visible to from the "view" tag.
+func getViews(tag reflect.StructTag) (views []string) {
+	for _, view := range strings.Split(struct_tag.Get(string(tag), `view`), ",") {
+		if view = strings.TrimSpace(view); view != `` {
+			views = append(views, view)
+		}
+	}
+	return
+}
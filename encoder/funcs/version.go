@@ -0,0 +1,87 @@
+package funcs
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareVersions compares two semantic versions like "v2.3", "2.3.1" or "v3.0.0-beta.1".
+// The leading "v" is optional, missing minor and patch numbers are taken as 0,
+// and a pre-release version has lower precedence than the associated normal version.
+// The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+func compareVersions(a, b string) int {
+	a, aPre := splitVersion(a)
+	b, bPre := splitVersion(b)
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for len(aParts) < len(bParts) {
+		aParts = append(aParts, "0")
+	}
+	for len(bParts) < len(aParts) {
+		bParts = append(bParts, "0")
+	}
+	for i := range aParts {
+		if c := compareIdentifiers(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	aParts, bParts = strings.Split(aPre, "."), strings.Split(bPre, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := compareIdentifiers(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(aParts), len(bParts))
+}
+
+// splitVersion strips the leading "v" and build metadata from version,
+// and splits it into the version core and the pre-release part.
+func splitVersion(version string) (core, preRelease string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version = version[:i]
+	}
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		return version[:i], version[i+1:]
+	}
+	return version, ""
+}
+
+// compareIdentifiers compares numeric identifiers numerically,
+// and other identifiers lexically in ASCII sort order.
+// Numeric identifiers always have lower precedence than non-numeric identifiers.
+func compareIdentifiers(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		if an < bn {
+			return -1
+		} else if an > bn {
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
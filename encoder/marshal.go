@@ -140,9 +140,14 @@ import (
 var bufferPool sync.Pool
 
 func Marshal(v interface{}, escapeHTML bool) ([]byte, error) {
+	return MarshalWithOptions(v, types.Options{EscapeHTML: escapeHTML})
+}
+
+// MarshalWithOptions is like Marshal but uses opts to control the encoding.
+func MarshalWithOptions(v interface{}, opts types.Options) ([]byte, error) {
 	b := getBuffer()
 
-	if err := marshal(b, v, types.Options{EscapeHTML: escapeHTML}); err != nil {
+	if err := marshal(b, v, opts); err != nil {
 		return nil, err
 	}
 	byts := append([]byte(nil), b.Bytes()...)
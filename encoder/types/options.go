@@ -1,6 +1,10 @@
 package types
 
-import "reflect"
+import (
+	"path"
+	"reflect"
+	"strings"
+)
 
 type Options struct {
 	// quoted causes primitive fields to be encoded inside JSON strings.
@@ -8,9 +12,40 @@ type Options struct {
 	// escapeHTML causes '<', '>', and '&' to be escaped in JSON strings.
 	EscapeHTML bool
 
+	// OmitDeprecated causes deprecated struct fields to be omitted.
+	OmitDeprecated bool
+	// APIVersion causes struct fields not valid in this api version to be omitted,
+	// according to their "since" and "until" tags. Empty means all versions.
+	APIVersion string
+	// OmitVersionNotes causes the " (since v)" notes of fields with a "since" tag
+	// to be omitted from comments.
+	OmitVersionNotes bool
+	// Views causes struct fields not visible to any of these audiences to be omitted,
+	// according to their "view" tags. Fields without a "view" tag are visible to all audiences.
+	// Empty means all audiences.
+	Views []string
+	// PathComments causes the JSON path of each struct field, array element and map element
+	// to be appended to its comment, like "data.items[].sku" or "data.prices.*".
+	PathComments bool
+	// SensitiveKeys causes values of struct fields or map entries to be masked,
+	// if their keys match any of these patterns case-insensitively.
+	// The pattern syntax is the same as in path.Match, like "password" or "*token".
+	// Struct fields with the "sensitive" option in "json" tag are always masked.
+	SensitiveKeys []string
+	// OmitComments causes comments to be omitted, so that the output is valid JSON.
+	OmitComments bool
+	// OmitPointerMark causes the "*" prefix of pointer struct fields to be omitted from keys.
+	OmitPointerMark bool
+
 	// comment to encode inside in struct, slice, array, map values
 	comment *string
 
+	// masked causes the value to be replaced by a type-preserving mask.
+	masked bool
+
+	// JSON path of the value being encoded, maintained when PathComments is true.
+	path string
+
 	// when convert empty slice/map/pointer to non empty ones,
 	// record the types has been converted in upper layers to check recursion.
 	convertedTypesInUpperLayers []reflect.Type
@@ -30,9 +65,49 @@ func (opts *Options) AppendConvertedTypeInUpperLayers(typ reflect.Type) {
 	opts.convertedTypesInUpperLayers = append(opts.convertedTypesInUpperLayers, typ)
 }
 
+// Masked reports whether the value should be replaced by a type-preserving mask.
+func (opts *Options) Masked() bool {
+	return opts.masked
+}
+
+// SetMasked causes the value and all its descendants to be masked.
+func (opts *Options) SetMasked() {
+	opts.masked = true
+}
+
+// IsSensitiveKey reports whether key matches any of SensitiveKeys.
+func (opts *Options) IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range opts.SensitiveKeys {
+		if ok, _ := path.Match(strings.ToLower(pattern), key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// The JSON path elements of array and map elements, like "data.items[].sku" or "data.prices.*".
+const (
+	ArrayElemPath = "[]"
+	MapElemPath   = ".*"
+)
+
+// Path returns the JSON path of the value being encoded, like "data.items[].sku".
+func (opts *Options) Path() string {
+	return opts.path
+}
+
+// AppendPath appends elem, like ".name" or "[]", to the JSON path.
+func (opts *Options) AppendPath(elem string) {
+	if opts.path == "" && elem[0] == '.' {
+		elem = elem[1:]
+	}
+	opts.path += elem
+}
+
 // set comment when encode struct field
 func (opts *Options) SetComment(comment, commentHTML string) {
-	if comment == "" {
+	if comment == "" || opts.OmitComments {
 		opts.comment = nil
 		return
 	}
@@ -0,0 +1,72 @@
+package jsondoc
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/lovego/jsondoc/ast"
+	"github.com/lovego/jsondoc/scanner"
+)
+
+// FormatOptions controls the output of Format.
+type FormatOptions struct {
+	// Prefix and Indent are the same as the prefix and indent of MarshalIndent.
+	Prefix, Indent string
+	// AlignComments aligns the comments after values on consecutive lines to the same column.
+	AlignComments bool
+	// SortKeys sorts the keys of objects, ignoring the "*" prefix of pointer fields.
+	SortKeys bool
+	// CommentSyntax is the syntax of comments in the output: "#" (the default), "//" or "/* */".
+	// Output with "//" or "/* */" comments is not strict jsondoc,
+	// so it can be read back by Format only with Lenient.
+	CommentSyntax string
+	// Lenient accepts the relaxed forms of lenient mode in src, and normalizes them.
+	Lenient bool
+}
+
+// A Warning reports a relaxed form of JSON accepted by Format with Lenient, at its position in src.
+type Warning = scanner.Warning
+
+// Format reformats the jsondoc text src, which may be already indented and edited by hand.
+// Every comment is preserved: comments after values stay after them, and comments on their own lines
+// stay on their own lines, before the values after them. If src ends with a newline, so does the result.
+// With Lenient, a warning is returned for each relaxed form normalized, in the order of their positions.
+func Format(src []byte, opts FormatOptions) ([]byte, []Warning, error) {
+	var warnings []Warning
+	if opts.Lenient {
+		var buf bytes.Buffer
+		var err error
+		if warnings, err = scanner.IndentLenient(&buf, src, "", ""); err != nil {
+			return nil, warnings, err
+		}
+		src = buf.Bytes()
+	}
+	n, err := ast.Parse(src)
+	if err != nil {
+		return nil, warnings, err
+	}
+	if opts.SortKeys {
+		sortKeys(n)
+	}
+	var buf bytes.Buffer
+	ast.PrintWithOptions(&buf, n, ast.PrintOptions{
+		Prefix: opts.Prefix, Indent: opts.Indent,
+		AlignComments: opts.AlignComments, CommentSyntax: opts.CommentSyntax,
+	})
+	if bytes.HasSuffix(src, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), warnings, nil
+}
+
+func sortKeys(n *ast.Node) {
+	if n.Kind == ast.Object {
+		sort.SliceStable(n.Children, func(i, j int) bool {
+			return strings.TrimPrefix(n.Children[i].Key, "*") < strings.TrimPrefix(n.Children[j].Key, "*")
+		})
+	}
+	for _, child := range n.Children {
+		sortKeys(child)
+	}
+}
@@ -0,0 +1,33 @@
+package jsondoc
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/lovego/jsondoc/render"
+)
+
+// MarshalHTML is like MarshalIndent but produces a standalone HTML page,
+// which renders the document as a collapsible tree with syntax highlighting,
+// anchors per JSON path, and a button to copy the document as JSON without comments.
+// The "*" prefix of pointer fields is omitted, so that the copied JSON can be used directly.
+func MarshalHTML(v interface{}) ([]byte, error) {
+	return MarshalHTMLWithOptions(v, Options{})
+}
+
+// MarshalHTMLWithOptions is like MarshalHTML but uses opts to control the output.
+func MarshalHTMLWithOptions(v interface{}, opts Options) ([]byte, error) {
+	n, err := parseMarshaled(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	title := "jsondoc"
+	if v != nil {
+		if name := reflect.TypeOf(v).Name(); name != "" {
+			title = name
+		}
+	}
+	var buf bytes.Buffer
+	render.HTML(&buf, n, title)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+// Package width measures the display width of text in a monospaced terminal,
+// so that comments and carets can be aligned under text with East Asian characters.
+package width
+
+import "unicode"
+
+// String returns the width of s in columns, with tabs expanded to multiples of 8,
+// East Asian wide characters taking 2 columns and combining marks taking none.
+func String(s string) int {
+	width := 0
+	for _, r := range s {
+		if r == '\t' {
+			width = width/8*8 + 8
+		} else {
+			width += Rune(r)
+		}
+	}
+	return width
+}
+
+// Rune returns the width of r in columns: 2 for East Asian wide characters,
+// 0 for combining marks and format characters, and 1 for others, including tab.
+func Rune(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether r is an East Asian wide or fullwidth character.
+func isWide(r rune) bool {
+	switch {
+	case r < 0x1100:
+		return false
+	case r <= 0x115F, // Hangul Jamo
+		0x2E80 <= r && r <= 0x303E, // CJK Radicals, Kangxi Radicals, CJK Symbols and Punctuation
+		0x3041 <= r && r <= 0x33FF, // Hiragana, Katakana, Bopomofo, Hangul Compatibility Jamo, ...
+		0x3400 <= r && r <= 0x4DBF, // CJK Unified Ideographs Extension A
+		0x4E00 <= r && r <= 0x9FFF, // CJK Unified Ideographs
+		0xA000 <= r && r <= 0xA4CF, // Yi
+		0xAC00 <= r && r <= 0xD7A3, // Hangul Syllables
+		0xF900 <= r && r <= 0xFAFF, // CJK Compatibility Ideographs
+		0xFE30 <= r && r <= 0xFE4F, // CJK Compatibility Forms
+		0xFF00 <= r && r <= 0xFF60, // Fullwidth Forms
+		0xFFE0 <= r && r <= 0xFFE6,
+		0x1F300 <= r && r <= 0x1F64F, // Miscellaneous Symbols and Pictographs, Emoticons
+		0x1F900 <= r && r <= 0x1F9FF, // Supplemental Symbols and Pictographs
+		0x20000 <= r && r <= 0x3FFFD: // CJK Unified Ideographs Extension B and later
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/render"
+)
+
+// JSON5Options controls the output of MarshalJSON5.
+type JSON5Options = render.JSON5Options
+
+// MarshalJSON5 is like MarshalIndent but produces JSON5,
+// with unquoted identifier keys and "//" comments.
+// The "*" prefix of pointer fields is omitted, so that the output can be used directly.
+// The output can be read back by scanner.ValidateLenient.
+func MarshalJSON5(v interface{}, json5Opts JSON5Options) ([]byte, error) {
+	return MarshalJSON5WithOptions(v, json5Opts, Options{})
+}
+
+// MarshalJSON5WithOptions is like MarshalJSON5 but uses opts to control the output.
+func MarshalJSON5WithOptions(v interface{}, json5Opts JSON5Options, opts Options) ([]byte, error) {
+	n, err := parseMarshaled(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	render.JSON5(&buf, n, json5Opts)
+	return buf.Bytes(), nil
+}
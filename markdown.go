@@ -0,0 +1,23 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/schema"
+)
+
+// MarkdownTable returns a Markdown table of the fields of v,
+// with columns for JSON path, type, required, example and description.
+// Nested objects are flattened into paths like "data.items[].sku".
+func MarkdownTable(v interface{}) ([]byte, error) {
+	return MarkdownTableWithOptions(v, Options{})
+}
+
+// MarkdownTableWithOptions is like MarkdownTable but uses opts to filter struct fields.
+func MarkdownTableWithOptions(v interface{}, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := schema.MarkdownTable(&buf, v, opts.encoderOptions()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
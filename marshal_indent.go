@@ -14,14 +14,66 @@ import (
 	"bytes"
 
 	"github.com/lovego/jsondoc/encoder"
+	"github.com/lovego/jsondoc/encoder/types"
 	"github.com/lovego/jsondoc/scanner"
 )
 
+// Options controls the output of MarshalIndentWithOptions and the other generators.
+type Options struct {
+	// EscapeHTML causes '<', '>', and '&' to be escaped in JSON strings.
+	EscapeHTML bool
+
+	// OmitDeprecated causes deprecated struct fields to be omitted.
+	OmitDeprecated bool
+	// APIVersion causes struct fields not valid in this api version to be omitted,
+	// according to their "since" and "until" tags. Empty means all versions.
+	APIVersion string
+	// OmitVersionNotes causes the " (since v)" notes of fields with a "since" tag
+	// to be omitted from comments.
+	OmitVersionNotes bool
+	// Views causes struct fields not visible to any of these audiences to be omitted,
+	// according to their "view" tags. Fields without a "view" tag are visible to all audiences.
+	// Empty means all audiences.
+	Views []string
+	// PathComments causes the JSON path of each struct field, array element and map element
+	// to be appended to its comment, like "data.items[].sku" or "data.prices.*".
+	PathComments bool
+	// SensitiveKeys causes values of struct fields or map entries to be masked,
+	// if their keys match any of these patterns case-insensitively.
+	// The pattern syntax is the same as in path.Match, like "password" or "*token".
+	// Struct fields with the "sensitive" option in "json" tag are always masked.
+	SensitiveKeys []string
+	// OmitComments causes comments to be omitted, so that the output is valid JSON.
+	OmitComments bool
+	// OmitPointerMark causes the "*" prefix of pointer struct fields to be omitted from keys.
+	OmitPointerMark bool
+}
+
+// encoderOptions converts opts to the options of the encoder.
+func (opts Options) encoderOptions() types.Options {
+	return types.Options{
+		EscapeHTML:       opts.EscapeHTML,
+		OmitDeprecated:   opts.OmitDeprecated,
+		APIVersion:       opts.APIVersion,
+		OmitVersionNotes: opts.OmitVersionNotes,
+		Views:            opts.Views,
+		PathComments:     opts.PathComments,
+		SensitiveKeys:    opts.SensitiveKeys,
+		OmitComments:     opts.OmitComments,
+		OmitPointerMark:  opts.OmitPointerMark,
+	}
+}
+
 // MarshalIndent is like json.Marshal but applies Indent to format the output.
 // Each JSON element in the output will begin on a new line beginning with prefix
 // followed by one or more copies of indent according to the indentation nesting.
 func MarshalIndent(v interface{}, escapeHTML bool, prefix, indent string) ([]byte, error) {
-	b, err := encoder.Marshal(v, escapeHTML)
+	return MarshalIndentWithOptions(v, prefix, indent, Options{EscapeHTML: escapeHTML})
+}
+
+// MarshalIndentWithOptions is like MarshalIndent but uses opts to control the output.
+func MarshalIndentWithOptions(v interface{}, prefix, indent string, opts Options) ([]byte, error) {
+	b, err := encoder.MarshalWithOptions(v, opts.encoderOptions())
 	if err != nil {
 		return nil, err
 	}
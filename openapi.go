@@ -0,0 +1,25 @@
+package jsondoc
+
+import (
+	"github.com/lovego/jsondoc/schema"
+)
+
+// OpenAPIComponents returns the "components.schemas" section of an OpenAPI document as JSON,
+// like `{"components":{"schemas":{...}}}`, with a schema for the type of each of values.
+// The version is "3.0" or "3.1" (the default). Schemas are named by their type names,
+// and have the values rendered by MarshalIndent as examples.
+// Pointers are "nullable" in OpenAPI 3.0, and also allow the "null" type in 3.1.
+// Use schema.Components to register types with other names.
+func OpenAPIComponents(version string, values ...interface{}) ([]byte, error) {
+	return OpenAPIComponentsWithOptions(version, Options{}, values...)
+}
+
+// OpenAPIComponentsWithOptions is like OpenAPIComponents but uses opts to filter struct fields
+// and to render the examples.
+func OpenAPIComponentsWithOptions(version string, opts Options, values ...interface{}) ([]byte, error) {
+	components := schema.NewComponents(version, opts.encoderOptions())
+	for _, v := range values {
+		components.Register("", v)
+	}
+	return schema.Marshal(components)
+}
@@ -0,0 +1,26 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/schema"
+)
+
+// Proto returns a proto3 file with messages for the types of values, which must be named structs.
+// Field names are converted to snake_case, with "json_name" options to keep the names of MarshalIndent,
+// comments become "//" comments, and field numbers are set by the "proto" tag, like `proto:"3"`,
+// or follow the order of struct fields. Set the numbers explicitly to keep wire compatibility
+// when fields are added or reordered.
+func Proto(pkg string, values ...interface{}) ([]byte, error) {
+	return ProtoWithOptions(pkg, Options{}, values...)
+}
+
+// ProtoWithOptions is like Proto but uses opts to filter struct fields.
+// Field numbers are not changed by the filtering.
+func ProtoWithOptions(pkg string, opts Options, values ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := schema.Proto(&buf, pkg, opts.encoderOptions(), values...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,173 @@
+package render
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lovego/jsondoc/ast"
+)
+
+// HTML appends to buf a standalone HTML page of the document n, titled title.
+// The document is rendered as a collapsible tree with syntax highlighting,
+// every value has an anchor of its JSON path (like "#data.items[0].sku", or `#data["a.b"]`
+// for keys which are not simple), made unique by a "~2" like suffix for duplicate keys,
+// and a button copies the document as JSON without comments.
+// The page has no external assets.
+func HTML(buf *bytes.Buffer, n *ast.Node, title string) {
+	buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	buf.WriteString(html.EscapeString(title))
+	buf.WriteString("</title>\n<style>\n")
+	buf.WriteString(htmlStyle)
+	buf.WriteString("</style>\n</head>\n<body>\n")
+	buf.WriteString("<button id=\"copy\" type=\"button\">Copy JSON</button>\n<div class=\"jsondoc\">\n")
+	h := htmlWriter{buf: buf, ids: map[string]bool{}}
+	h.node(n, "", false, true)
+	buf.WriteString("</div>\n<pre id=\"json\" hidden>")
+	var json bytes.Buffer
+	writeJSON(&json, n, "  ", 0)
+	buf.WriteString(html.EscapeString(json.String()))
+	buf.WriteString("</pre>\n<script>\n")
+	buf.WriteString(htmlScript)
+	buf.WriteString("</script>\n</body>\n</html>\n")
+}
+
+type htmlWriter struct {
+	buf *bytes.Buffer
+	ids map[string]bool // the anchor ids used
+}
+
+// node writes node n with JSON path path. If hasKey is true, the key of n is written,
+// even if it's empty. If last is false, a comma is written after it.
+func (h *htmlWriter) node(n *ast.Node, path string, hasKey, last bool) {
+	buf := h.buf
+	anchor := h.anchor(path)
+	if n.IsScalar() || len(n.Children) == 0 {
+		buf.WriteString(`<div class="line" id="` + html.EscapeString(anchor) + `">`)
+		htmlAnchor(buf, anchor)
+		htmlKey(buf, n, hasKey)
+		buf.WriteString(htmlScalar(n))
+		if !last {
+			buf.WriteByte(',')
+		}
+		htmlComment(buf, n.CommentText())
+		buf.WriteString("</div>\n")
+		return
+	}
+
+	open, end := "{", "}"
+	if n.Kind == ast.Array {
+		open, end = "[", "]"
+	}
+	buf.WriteString(`<details open id="` + html.EscapeString(anchor) + `"><summary>`)
+	htmlAnchor(buf, anchor)
+	htmlKey(buf, n, hasKey)
+	buf.WriteString(open)
+	buf.WriteString(`<span class="ellipsis">…` + end)
+	if !last {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`</span>`)
+	htmlComment(buf, n.CommentText())
+	buf.WriteString("</summary>\n<div class=\"children\">\n")
+	for i, child := range n.Children {
+		var childPath string
+		switch {
+		case n.Kind == ast.Array:
+			childPath = path + "[" + strconv.Itoa(i) + "]"
+		case !isSimpleKey(child.Key):
+			childPath = path + "[" + quoteJSON(child.Key) + "]"
+		case path == "":
+			childPath = child.Key
+		default:
+			childPath = path + "." + child.Key
+		}
+		h.node(child, childPath, n.Kind == ast.Object, i == len(n.Children)-1)
+	}
+	buf.WriteString("</div>\n<div class=\"line\">" + end)
+	if !last {
+		buf.WriteByte(',')
+	}
+	buf.WriteString("</div>\n</details>\n")
+}
+
+// anchor returns an unused anchor id for JSON path path.
+func (h *htmlWriter) anchor(path string) string {
+	if path == "" {
+		path = "$"
+	}
+	id := path
+	for i := 2; h.ids[id]; i++ {
+		id = path + "~" + strconv.Itoa(i)
+	}
+	h.ids[id] = true
+	return id
+}
+
+// isSimpleKey reports whether key can be written in a JSON path after a ".".
+func isSimpleKey(key string) bool {
+	return key != "" && !strings.ContainsAny(key, ".[]\"~ \t\r\n")
+}
+
+func htmlAnchor(buf *bytes.Buffer, id string) {
+	href := (&url.URL{Fragment: id}).String()
+	buf.WriteString(`<a class="anchor" href="` + html.EscapeString(href) + `">#</a>`)
+}
+
+func htmlKey(buf *bytes.Buffer, n *ast.Node, hasKey bool) {
+	if hasKey {
+		buf.WriteString(`<span class="key">` + html.EscapeString(quoteJSON(n.Key)) + `</span>: `)
+	}
+}
+
+func htmlScalar(n *ast.Node) string {
+	switch n.Kind {
+	case ast.Object:
+		return "{}"
+	case ast.Array:
+		return "[]"
+	}
+	return `<span class="` + n.Kind.String() + `">` + html.EscapeString(n.Value) + `</span>`
+}
+
+func htmlComment(buf *bytes.Buffer, comment string) {
+	if comment != "" {
+		buf.WriteString(`<span class="comment"># ` + html.EscapeString(comment) + `</span>`)
+	}
+}
+
+const htmlStyle = `body { font-family: sans-serif; }
+.jsondoc { font-family: Menlo, Consolas, monospace; font-size: 14px; line-height: 1.5; }
+.jsondoc .children { padding-left: 2em; }
+.jsondoc summary { cursor: pointer; list-style: none; }
+.jsondoc summary::-webkit-details-marker { display: none; }
+.jsondoc summary::before { content: "▾"; display: inline-block; width: 1em; margin-left: -1em; color: #999; }
+.jsondoc details:not([open]) > summary::before { content: "▸"; }
+.jsondoc details[open] > summary .ellipsis { display: none; }
+.jsondoc .anchor { visibility: hidden; color: #999; text-decoration: none; margin-left: -2em; width: 1em; display: inline-block; }
+.jsondoc .line:hover > .anchor, .jsondoc summary:hover > .anchor { visibility: visible; }
+.jsondoc :target { background: #fff8c5; }
+.jsondoc .key { color: #a31515; }
+.jsondoc .string { color: #0b7500; }
+.jsondoc .number { color: #1750eb; }
+.jsondoc .bool, .jsondoc .null { color: #871094; }
+.jsondoc .comment { color: #8c8c8c; font-style: italic; margin-left: 1em; }
+#copy { margin-bottom: 1em; }
+`
+
+const htmlScript = `document.getElementById("copy").addEventListener("click", function () {
+  var json = document.getElementById("json").textContent;
+  if (navigator.clipboard) {
+    navigator.clipboard.writeText(json);
+    return;
+  }
+  var textarea = document.createElement("textarea");
+  textarea.value = json;
+  document.body.appendChild(textarea);
+  textarea.select();
+  document.execCommand("copy");
+  document.body.removeChild(textarea);
+});
+`
@@ -0,0 +1,37 @@
+package render
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/ast"
+)
+
+// writeJSON writes the document n as indented JSON without comments.
+func writeJSON(buf *bytes.Buffer, n *ast.Node, indent string, depth int) {
+	if n.IsScalar() {
+		buf.WriteString(n.Value)
+		return
+	}
+	open, end := byte('{'), byte('}')
+	if n.Kind == ast.Array {
+		open, end = '[', ']'
+	}
+	buf.WriteByte(open)
+	for i, child := range n.Children {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+		writeIndent(buf, indent, depth+1)
+		if n.Kind == ast.Object {
+			buf.WriteString(quoteJSON(child.Key))
+			buf.WriteString(": ")
+		}
+		writeJSON(buf, child, indent, depth+1)
+	}
+	if len(n.Children) > 0 {
+		buf.WriteByte('\n')
+		writeIndent(buf, indent, depth)
+	}
+	buf.WriteByte(end)
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"github.com/lovego/jsondoc/ast"
+)
+
+// JSON5Options controls the output of JSON5.
+type JSON5Options struct {
+	// Indent is the indentation string, two spaces if empty.
+	Indent string
+	// TrailingCommas causes a comma to be written after the last member of objects and arrays.
+	TrailingCommas bool
+	// SingleQuotes causes strings to be single-quoted.
+	SingleQuotes bool
+}
+
+// JSON5 appends to buf the JSON5 form of the document n.
+// Keys are unquoted if they are identifiers, and comments become "//" comments.
+func JSON5(buf *bytes.Buffer, n *ast.Node, opts JSON5Options) {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	json5Node(buf, n, opts, 0)
+	json5Comment(buf, n.CommentText())
+	buf.WriteByte('\n')
+}
+
+func json5Node(buf *bytes.Buffer, n *ast.Node, opts JSON5Options, depth int) {
+	switch n.Kind {
+	case ast.String:
+		buf.WriteString(json5String(n.Text(), opts.SingleQuotes))
+		return
+	case ast.Object, ast.Array:
+	default:
+		buf.WriteString(n.Value)
+		return
+	}
+
+	open, end := byte('{'), byte('}')
+	if n.Kind == ast.Array {
+		open, end = '[', ']'
+	}
+	buf.WriteByte(open)
+	if len(n.Children) == 0 {
+		buf.WriteByte(end)
+		return
+	}
+	if depth > 0 {
+		json5Comment(buf, n.CommentText())
+	}
+	for i, child := range n.Children {
+		buf.WriteByte('\n')
+		writeIndent(buf, opts.Indent, depth+1)
+		if n.Kind == ast.Object {
+			buf.WriteString(json5Key(child.Key, opts.SingleQuotes))
+			buf.WriteString(": ")
+		}
+		json5Node(buf, child, opts, depth+1)
+		if i < len(n.Children)-1 || opts.TrailingCommas {
+			buf.WriteByte(',')
+		}
+		if child.IsScalar() || len(child.Children) == 0 {
+			json5Comment(buf, child.CommentText())
+		}
+	}
+	buf.WriteByte('\n')
+	writeIndent(buf, opts.Indent, depth)
+	buf.WriteByte(end)
+}
+
+func json5Comment(buf *bytes.Buffer, comment string) {
+	if comment != "" {
+		buf.WriteString(" // ")
+		buf.WriteString(comment)
+	}
+}
+
+// json5Key returns key unquoted if it's an identifier, otherwise quoted.
+func json5Key(key string, singleQuotes bool) string {
+	if isIdentifier(key) {
+		return key
+	}
+	return json5String(key, singleQuotes)
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if !(c == '_' || c == '$' || unicode.IsLetter(c) || i > 0 && unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// json5String returns s as a double-quoted or single-quoted string.
+func json5String(s string, singleQuotes bool) string {
+	quoted := quoteJSON(s)
+	if !singleQuotes {
+		return quoted
+	}
+	quoted = quoted[1 : len(quoted)-1]
+	quoted = strings.Replace(quoted, `\"`, `"`, -1)
+	quoted = strings.Replace(quoted, `'`, `\'`, -1)
+	return "'" + quoted + "'"
+}
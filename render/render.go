@@ -0,0 +1,42 @@
+// Package render renders jsondoc documents in formats other than JSON,
+// keeping the comments of the document.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+func writeIndent(buf *bytes.Buffer, indent string, depth int) {
+	for i := 0; i < depth; i++ {
+		buf.WriteString(indent)
+	}
+}
+
+// writeComment writes comment after a value, prefixed by two spaces and "# ".
+func writeComment(buf *bytes.Buffer, comment string) {
+	if comment != "" {
+		buf.WriteString("  # ")
+		buf.WriteString(comment)
+	}
+}
+
+// isReserved reports whether s is read as a non-string value by YAML or TOML.
+func isReserved(s string) bool {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~",
+		"inf", "+inf", "-inf", ".inf", "+.inf", "-.inf", "nan", ".nan":
+		return true
+	}
+	return false
+}
+
+// quoteJSON returns s as a JSON string, without escaping HTML characters.
+func quoteJSON(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,148 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/lovego/jsondoc/ast"
+)
+
+// TOML appends to buf the TOML form of the document n, which must be an object.
+// Nested objects become [table] sections, arrays of objects become [[array]] tables,
+// and comments become "# " lines above keys. TOML has no null, so null values
+// are written as commented out keys, and are dropped from arrays and inline tables.
+// Arrays which are not arrays of tables are written inline, so comments of values
+// nested in them are lost.
+func TOML(buf *bytes.Buffer, n *ast.Node) error {
+	if n.Kind != ast.Object {
+		return errors.New("jsondoc: TOML document must be an object, not " + n.Kind.String())
+	}
+	if n.CommentText() != "" {
+		buf.WriteString("# " + n.CommentText() + "\n")
+	}
+	tomlTable(buf, n, "")
+	return nil
+}
+
+// tomlTable writes the members of table n, whose header has been written.
+func tomlTable(buf *bytes.Buffer, n *ast.Node, path string) {
+	// Key/value pairs must be written before sub tables.
+	var tables []*ast.Node
+	for _, child := range n.Children {
+		if isTable(child) || isArrayOfTables(child) {
+			tables = append(tables, child)
+			continue
+		}
+		if child.CommentText() != "" {
+			buf.WriteString("# " + child.CommentText() + "\n")
+		}
+		if child.Kind == ast.Null {
+			buf.WriteString("# ")
+		}
+		buf.WriteString(tomlKey(child.Key) + " = " + tomlInline(child) + "\n")
+	}
+
+	for _, child := range tables {
+		childPath := tomlKey(child.Key)
+		if path != "" {
+			childPath = path + "." + childPath
+		}
+		if isTable(child) {
+			buf.WriteByte('\n')
+			if child.CommentText() != "" {
+				buf.WriteString("# " + child.CommentText() + "\n")
+			}
+			buf.WriteString("[" + childPath + "]\n")
+			tomlTable(buf, child, childPath)
+			continue
+		}
+		for i, elem := range nonNulls(child.Children) {
+			buf.WriteByte('\n')
+			if i == 0 && child.CommentText() != "" {
+				buf.WriteString("# " + child.CommentText() + "\n")
+			}
+			buf.WriteString("[[" + childPath + "]]\n")
+			tomlTable(buf, elem, childPath)
+		}
+	}
+}
+
+// tomlInline returns the inline form of node n, which is not null.
+func tomlInline(n *ast.Node) string {
+	switch n.Kind {
+	case ast.String:
+		return quoteJSON(n.Text())
+	case ast.Object:
+		children := nonNulls(n.Children)
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, child := range children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(" " + tomlKey(child.Key) + " = " + tomlInline(child))
+		}
+		if len(children) > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteByte('}')
+		return buf.String()
+	case ast.Array:
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, child := range nonNulls(n.Children) {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(tomlInline(child))
+		}
+		buf.WriteByte(']')
+		return buf.String()
+	}
+	return n.Value
+}
+
+// tomlKey returns key as a bare key if possible, otherwise as a quoted key.
+func tomlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == '-') {
+			return quoteJSON(key)
+		}
+	}
+	return key
+}
+
+// isTable reports whether n is written as a [table] section.
+func isTable(n *ast.Node) bool {
+	return n.Kind == ast.Object && len(n.Children) > 0
+}
+
+// isArrayOfTables reports whether n is written as [[array]] tables.
+// Null elements are ignored.
+func isArrayOfTables(n *ast.Node) bool {
+	elems := nonNulls(n.Children)
+	if n.Kind != ast.Array || len(elems) == 0 {
+		return false
+	}
+	for _, elem := range elems {
+		if elem.Kind != ast.Object {
+			return false
+		}
+	}
+	return true
+}
+
+// nonNulls returns the nodes which are not null.
+func nonNulls(nodes []*ast.Node) []*ast.Node {
+	result := make([]*ast.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Kind != ast.Null {
+			result = append(result, n)
+		}
+	}
+	return result
+}
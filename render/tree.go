@@ -0,0 +1,63 @@
+package render
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/lovego/jsondoc/ast"
+	"github.com/lovego/jsondoc/internal/width"
+)
+
+// Tree appends to buf a plain-text tree of the document n, like the output of the "tree" command,
+// with a type after each key and the comments aligned in a column:
+//
+//	├── name (string)  名称
+//
+// Array elements are shown as their indexes, like "[0]", and empty keys as `""`,
+// like the key of an expanded empty map.
+func Tree(buf *bytes.Buffer, n *ast.Node) {
+	var lines []treeLine
+	lines = append(lines, treeLine{text: ". (" + n.Kind.String() + ")", comment: n.CommentText()})
+	lines = treeChildren(lines, n, "")
+
+	maxWidth := 0
+	for _, line := range lines {
+		if w := width.String(line.text); w > maxWidth && line.comment != "" {
+			maxWidth = w
+		}
+	}
+	for _, line := range lines {
+		buf.WriteString(line.text)
+		if line.comment != "" {
+			buf.WriteString(strings.Repeat(" ", maxWidth-width.String(line.text)+2))
+			buf.WriteString(line.comment)
+		}
+		buf.WriteByte('\n')
+	}
+}
+
+type treeLine struct {
+	text, comment string
+}
+
+func treeChildren(lines []treeLine, n *ast.Node, prefix string) []treeLine {
+	for i, child := range n.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(n.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		name := child.Key
+		if n.Kind == ast.Array {
+			name = "[" + strconv.Itoa(i) + "]"
+		} else if name == "" {
+			name = `""`
+		}
+		lines = append(lines, treeLine{
+			text:    prefix + branch + name + " (" + child.Kind.String() + ")",
+			comment: child.CommentText(),
+		})
+		lines = treeChildren(lines, child, prefix+indent)
+	}
+	return lines
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/lovego/jsondoc/ast"
+)
+
+// YAML appends to buf the YAML form of the document n.
+// Objects become mappings, arrays become "- " sequences, and comments become trailing "# " comments.
+func YAML(buf *bytes.Buffer, n *ast.Node) {
+	if n.CommentText() != "" && !isEmpty(n) {
+		buf.WriteString("# ")
+		buf.WriteString(n.CommentText())
+		buf.WriteByte('\n')
+	}
+	switch {
+	case n.Kind == ast.Object && !isEmpty(n):
+		yamlMapping(buf, n, 0, true)
+	case n.Kind == ast.Array && !isEmpty(n):
+		yamlSequence(buf, n, 0, true)
+	default:
+		buf.WriteString(yamlScalar(n))
+		writeComment(buf, n.CommentText())
+	}
+	buf.WriteByte('\n')
+}
+
+// yamlMapping writes the members of object n at depth.
+// If inline is true, the first member is written on the current line.
+func yamlMapping(buf *bytes.Buffer, n *ast.Node, depth int, inline bool) {
+	for i, child := range n.Children {
+		if i > 0 || !inline {
+			buf.WriteByte('\n')
+			writeIndent(buf, "  ", depth)
+		}
+		buf.WriteString(yamlString(child.Key))
+		buf.WriteByte(':')
+		switch {
+		case child.Kind == ast.Object && !isEmpty(child):
+			writeComment(buf, child.CommentText())
+			yamlMapping(buf, child, depth+1, false)
+		case child.Kind == ast.Array && !isEmpty(child):
+			writeComment(buf, child.CommentText())
+			yamlSequence(buf, child, depth+1, false)
+		default:
+			buf.WriteByte(' ')
+			buf.WriteString(yamlScalar(child))
+			writeComment(buf, child.CommentText())
+		}
+	}
+}
+
+// yamlSequence writes the elements of array n at depth.
+// If inline is true, the first element is written on the current line.
+func yamlSequence(buf *bytes.Buffer, n *ast.Node, depth int, inline bool) {
+	for i, child := range n.Children {
+		if i > 0 || !inline {
+			buf.WriteByte('\n')
+			writeIndent(buf, "  ", depth)
+		}
+		buf.WriteByte('-')
+		switch {
+		case child.IsScalar() || isEmpty(child):
+			buf.WriteByte(' ')
+			buf.WriteString(yamlScalar(child))
+			writeComment(buf, child.CommentText())
+		case child.CommentText() != "":
+			writeComment(buf, child.CommentText())
+			yamlNested(buf, child, depth+1, false)
+		default:
+			buf.WriteByte(' ')
+			yamlNested(buf, child, depth+1, true)
+		}
+	}
+}
+
+func yamlNested(buf *bytes.Buffer, n *ast.Node, depth int, inline bool) {
+	if n.Kind == ast.Object {
+		yamlMapping(buf, n, depth, inline)
+	} else {
+		yamlSequence(buf, n, depth, inline)
+	}
+}
+
+// yamlScalar returns the YAML form of a scalar or empty node.
+func yamlScalar(n *ast.Node) string {
+	switch n.Kind {
+	case ast.Object:
+		return "{}"
+	case ast.Array:
+		return "[]"
+	case ast.String:
+		return yamlString(n.Text())
+	}
+	return n.Value
+}
+
+// yamlString returns s as a plain scalar if it's safe, otherwise as a double-quoted scalar.
+func yamlString(s string) string {
+	if isPlain(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// isPlain reports whether s can be written as a plain scalar of YAML and a bare key of TOML.
+func isPlain(s string) bool {
+	if s == "" || isReserved(s) {
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return s[0] != '-'
+}
+
+func isEmpty(n *ast.Node) bool {
+	return !n.IsScalar() && len(n.Children) == 0
+}
@@ -4,14 +4,55 @@ import "bytes"
 
 var hex = "0123456789abcdef"
 
+// CommentMode controls how comments are handled by CompactComments.
+type CommentMode int
+
+const (
+	// KeepComments keeps comments as they are, with the newlines ending them.
+	KeepComments CommentMode = iota
+	// DropComments removes comments, so that the result is valid JSON.
+	DropComments
+	// BlockComments converts comments to "/* */" comments on one line,
+	// so that the result is on one line.
+	BlockComments
+)
+
 // Compact appends to dst the JSON-encoded src with
 // insignificant space characters elided.
+// Comments are preserved, with the newlines ending them.
 func Compact(dst *bytes.Buffer, src []byte, escape bool) error {
+	return CompactComments(dst, src, escape, KeepComments)
+}
+
+// CompactComments is like Compact, but handles comments by mode.
+// Unless mode is KeepComments, "/* */" comments are also accepted in src,
+// so that the result of BlockComments can be compacted again.
+func CompactComments(dst *bytes.Buffer, src []byte, escape bool, mode CommentMode) error {
 	origLen := dst.Len()
-	var scan scanner
+	scan := scanner{blockComments: mode != KeepComments}
 	scan.reset()
 	start := 0
+	commentStart := -1
 	for i, c := range src {
+		v := scan.next(c)
+		if commentStart >= 0 {
+			if v == scanContinue {
+				continue
+			}
+			if v == scanError {
+				break
+			}
+			writeComment(dst, src[commentStart:i+1], mode)
+			commentStart, start = -1, i+1
+			continue
+		}
+		if v == scanBeginComment {
+			if start < i {
+				dst.Write(src[start:i])
+			}
+			commentStart = i
+			continue
+		}
 		if escape && (c == '<' || c == '>' || c == '&') {
 			if start < i {
 				dst.Write(src[start:i])
@@ -30,7 +71,6 @@ func Compact(dst *bytes.Buffer, src []byte, escape bool) error {
 			dst.WriteByte(hex[src[i+2]&0xF])
 			start = i + 3
 		}
-		v := scan.step(&scan, c)
 		if v >= scanSkipSpace {
 			if v == scanError {
 				break
@@ -45,8 +85,43 @@ func Compact(dst *bytes.Buffer, src []byte, escape bool) error {
 		dst.Truncate(origLen)
 		return scan.err
 	}
-	if start < len(src) {
+	if commentStart >= 0 {
+		writeComment(dst, src[commentStart:], mode)
+	} else if start < len(src) {
 		dst.Write(src[start:])
 	}
 	return nil
 }
+
+// writeComment writes comment, including the newline or "*/" ending it if any, by mode.
+func writeComment(dst *bytes.Buffer, comment []byte, mode CommentMode) {
+	switch mode {
+	case DropComments:
+	case BlockComments:
+		if !bytes.HasPrefix(comment, []byte("/*")) {
+			// strip the "#".
+			comment = bytes.TrimPrefix(comment, []byte("#"))
+			comment = bytes.TrimSpace(comment)
+			comment = bytes.Replace(comment, []byte("*/"), []byte("* /"), -1)
+			comment = append(append([]byte("/* "), comment...), " */"...)
+		}
+		// join the lines of a "/* */" comment.
+		lines := bytes.Split(comment, []byte("\n"))
+		for i, line := range lines {
+			if i > 0 {
+				dst.WriteByte(' ')
+				line = bytes.TrimLeft(line, " \t")
+			}
+			if i < len(lines)-1 {
+				line = bytes.TrimRight(line, " \t\r")
+			}
+			dst.Write(line)
+		}
+	default:
+		dst.Write(comment)
+		if comment[len(comment)-1] != '\n' {
+			// a comment at the end of src still needs a newline to end it.
+			dst.WriteByte('\n')
+		}
+	}
+}
@@ -19,61 +19,160 @@ import (
 // at the end of src are preserved and copied to dst.
 // For example, if src has no trailing spaces, neither will dst;
 // if src ends in a trailing newline, so will dst.
+// Comments after a token on the same line are formatted after a tab,
+// and comments on their own lines are kept on their own lines.
 func Indent(dst *bytes.Buffer, src []byte, prefix, indent string) error {
+	_, err := indentSrc(dst, src, prefix, indent, false)
+	return err
+}
+
+func indentSrc(dst *bytes.Buffer, src []byte, prefix, indent string, lenient bool) ([]Warning, error) {
 	origLen := dst.Len()
-	var scan scanner
+	scan := scanner{lenient: lenient}
 	scan.reset()
 	depth := 0
 	needNewline := false
+	// there is a newline in src since the last token, so that a comment is on its own line.
+	lineBreak := true
+	inComment := false
+	// the end of the last token in dst, and whether there are comments after it.
+	tokenEnd, commented := origLen, false
+	// spaces after the top-level value.
+	var trailing []byte
+	// the quotation mark of the string being written, and whether the last byte is a backslash.
+	var quote byte
+	escaped := false
+	// an unquoted object key is being written, the first '/' of a comment is just written.
+	identifier, slash := false, false
+	// a "/* */" comment is being written as a "#" comment, and the count of '*' not written yet.
+	block, stars := false, 0
+	// the offset in dst of the comma written as the last token, or -1.
+	commaAt := -1
 	for _, c := range src {
-		scan.bytes++
-		v := scan.step(&scan, c)
+		v := scan.next(c)
+		if identifier && v != scanContinue {
+			dst.WriteByte('"')
+			identifier = false
+			tokenEnd = dst.Len()
+		}
 		if v == scanSkipSpace {
+			if c == '\n' {
+				lineBreak = true
+			}
 			continue
 		}
 		if v == scanError {
 			break
 		}
+		if v == scanEnd {
+			if c == '\n' {
+				lineBreak = true
+			}
+			trailing = append(trailing, c)
+			continue
+		}
+		if inComment {
+			if slash {
+				// "//" and "/* */" comments are normalized to "#" comments in lenient mode.
+				dst.Truncate(dst.Len() - 1)
+				dst.WriteByte('#')
+				slash, block = false, c == '*'
+				continue
+			}
+			if block && v == scanContinue {
+				writeBlockCommentByte(dst, c, &stars)
+				continue
+			}
+			if v == scanContinue {
+				dst.WriteByte(c)
+				continue
+			}
+			if block {
+				// the "*/" ending a "/* */" comment.
+				block, stars = false, 0
+				trimRightSpaces(dst, origLen)
+			}
+			// the newline ending the comment is delayed,
+			// so that closing brackets are not preceded by an empty line.
+			inComment, lineBreak, needNewline = false, true, true
+			continue
+		}
+		if v == scanBeginComment {
+			if !lineBreak {
+				// a comment on the same line is formatted after a tab.
+				trimRightSpaces(dst, origLen)
+				dst.WriteString("\t ")
+			} else if dst.Len() > origLen {
+				// a comment on its own line is formatted on its own line.
+				newline(dst, prefix, indent, depth)
+			}
+			needNewline, trailing = false, trailing[:0]
+			inComment, commented, slash = true, true, c == '/'
+			dst.WriteByte(c)
+			continue
+		}
+		lineBreak = false
+
+		if commented && v != scanContinue && (c == ',' || c == ':') {
+			// move the punctuation before the comments after the last token,
+			// and leave the line ended by the comments.
+			insertByte(dst, tokenEnd, c)
+			if c == ',' {
+				commaAt = tokenEnd
+			}
+			tokenEnd++
+			lineBreak = true
+			continue
+		}
 		if needNewline {
 			needNewline = false
-			if v != scanEndObject && v != scanEndArray && v != scanBeginComment {
+			if v != scanEndObject && v != scanEndArray {
 				newline(dst, prefix, indent, depth)
 			}
 		}
 
-		switch v {
+		switch {
 		// Emit semantically uninteresting bytes
 		// (in particular, punctuation in strings) unmodified.
-		case scanContinue:
+		case v == scanContinue && quote != 0:
+			quote = writeStringByte(dst, c, quote, &escaped)
+
+		case v == scanContinue:
 			dst.WriteByte(c)
-			continue
-		case scanBeginComment:
-			dst.WriteString("\t ")
+
+		// Normalize single-quoted strings and unquoted object keys in lenient mode.
+		case v == scanBeginLiteral && (c == '"' || c == '\''):
+			dst.WriteByte('"')
+			quote = c
+
+		case v == scanBeginLiteral && isIdentifierStart(c) && scan.inObjectKey():
+			dst.WriteByte('"')
 			dst.WriteByte(c)
-			continue
-		case scanEndComment:
-			newline(dst, prefix, indent, depth)
-			continue
-		}
+			identifier = true
 
 		// Add spacing around real punctuation.
-		switch c {
-		case '{', '[':
+		case c == '{' || c == '[':
 			dst.WriteByte(c)
 			depth++
-			// delay newline so that empty object and array are formatted as {} and [].
+			// delay newline so that comments are formatted on the same line.
 			needNewline = true
 
-		case ',':
+		case c == ',':
 			dst.WriteByte(c)
-			// delay newline so that comment are formatted on the same line.
+			// delay newline so that comments are formatted on the same line.
 			needNewline = true
+			tokenEnd, commented, commaAt = dst.Len(), false, dst.Len()-1
+			continue
 
-		case ':':
+		case c == ':':
 			dst.WriteByte(c)
 			dst.WriteByte(' ')
 
-		case '}', ']':
+		case c == '}' || c == ']':
+			if commaAt >= 0 {
+				// remove the trailing comma in lenient mode.
+				deleteByte(dst, commaAt)
+			}
 			depth--
 			newline(dst, prefix, indent, depth)
 			dst.WriteByte(c)
@@ -81,12 +180,19 @@ func Indent(dst *bytes.Buffer, src []byte, prefix, indent string) error {
 		default:
 			dst.WriteByte(c)
 		}
+		tokenEnd, commented, commaAt = dst.Len(), false, -1
 	}
 	if scan.eof() == scanError {
 		dst.Truncate(origLen)
-		return scan.err
+		return scan.sortedWarnings(), scan.err
+	}
+	if needNewline {
+		// the newline ending a comment after the top-level value.
+		dst.WriteByte('\n')
 	}
-	return nil
+	// spaces after the top-level value are preserved, unless followed by comments.
+	dst.Write(trailing)
+	return scan.sortedWarnings(), nil
 }
 
 func newline(dst *bytes.Buffer, prefix, indent string, depth int) {
@@ -96,3 +202,60 @@ func newline(dst *bytes.Buffer, prefix, indent string, depth int) {
 		dst.WriteString(indent)
 	}
 }
+
+// writeStringByte writes c of a string literal quoted by quote as a byte of a double-quoted string,
+// and returns the quotation mark of the string, or 0 if c ends the string.
+func writeStringByte(dst *bytes.Buffer, c, quote byte, escaped *bool) byte {
+	switch {
+	case *escaped:
+		*escaped = false
+		if c == '\'' {
+			// `\'` is not a valid escape in JSON.
+			dst.Truncate(dst.Len() - 1)
+		}
+	case c == '\\':
+		*escaped = true
+	case c == quote:
+		dst.WriteByte('"')
+		return 0
+	case c == '"':
+		dst.WriteByte('\\')
+	}
+	dst.WriteByte(c)
+	return quote
+}
+
+// writeBlockCommentByte writes c of a "/* */" comment as a byte of a "#" comment.
+// Lines are joined by spaces, and '*' is delayed until it's known not to begin the "*/".
+func writeBlockCommentByte(dst *bytes.Buffer, c byte, stars *int) {
+	if c == '*' {
+		*stars++
+		return
+	}
+	for ; *stars > 0; *stars-- {
+		dst.WriteByte('*')
+	}
+	switch c {
+	case ' ', '\t', '\r', '\n':
+		if b := dst.Bytes(); b[len(b)-1] != ' ' {
+			dst.WriteByte(' ')
+		}
+	default:
+		dst.WriteByte(c)
+	}
+}
+
+// deleteByte deletes the byte at offset i from dst.
+func deleteByte(dst *bytes.Buffer, i int) {
+	b := dst.Bytes()
+	copy(b[i:], b[i+1:])
+	dst.Truncate(len(b) - 1)
+}
+
+// insertByte inserts c into dst at offset i.
+func insertByte(dst *bytes.Buffer, i int, c byte) {
+	tail := append([]byte(nil), dst.Bytes()[i:]...)
+	dst.Truncate(i)
+	dst.WriteByte(c)
+	dst.Write(tail)
+}
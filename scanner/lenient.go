@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// A Warning reports a relaxed form of JSON accepted in lenient mode:
+// a trailing comma, a single-quoted string, an escaped single quote, an unquoted object key,
+// or a "//" or "/* */" comment.
+type Warning struct {
+	Pos Position
+	Msg string
+}
+
+func (w Warning) String() string {
+	return strconv.Itoa(w.Pos.Line) + ":" + strconv.Itoa(w.Pos.Column) + ": " + w.Msg
+}
+
+// CheckLenient is like ValidateLenient, but also returns a warning for each relaxed form in src.
+func CheckLenient(src []byte) ([]Warning, error) {
+	scan := scanner{lenient: true}
+	scan.reset()
+	for _, c := range src {
+		if scan.next(c) == scanError {
+			return scan.sortedWarnings(), scan.err
+		}
+	}
+	if scan.eof() == scanError {
+		return scan.sortedWarnings(), scan.err
+	}
+	return scan.sortedWarnings(), nil
+}
+
+// IndentLenient is like Indent, but accepts the relaxed forms of lenient mode, and normalizes them:
+// trailing commas are removed, single-quoted strings and unquoted object keys are double-quoted,
+// and "//" and "/* */" comments are converted to "#" comments, with the lines of "/* */" comments joined.
+// A warning is returned for each relaxed form.
+func IndentLenient(dst *bytes.Buffer, src []byte, prefix, indent string) ([]Warning, error) {
+	return indentSrc(dst, src, prefix, indent, true)
+}
@@ -10,11 +10,17 @@ package scanner
 // otherwise common code from the multiple scanning functions
 // in this package (Compact, Indent, checkValid, etc).
 //
-import "strconv"
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lovego/jsondoc/internal/width"
+)
 
 // A scanner is a JSON scanning state machine.
 // Callers call scan.reset() and then pass bytes in one at a time
-// by calling scan.step(&scan, c) for each byte.
+// by calling scan.next(c) for each byte.
 // The return value, referred to as an opcode, tells the
 // caller about significant parsing events like beginning
 // and ending literals, objects, and arrays, so that the
@@ -42,6 +48,29 @@ type scanner struct {
 
 	// total bytes consumed, updated by decoder.Decode
 	bytes int64
+
+	// line and column in runes of the byte being scanned, starting at 1.
+	line, column int
+	// the byte being scanned follows a newline.
+	newline bool
+
+	// lenient causes the relaxed forms of JSON5 to be accepted:
+	// unquoted object keys, single-quoted strings, trailing commas, and "//" and "/* */" comments.
+	lenient bool
+	// blockComments causes "/* */" comments to be accepted, as written by CompactComments.
+	blockComments bool
+
+	// quotation mark of the string being scanned, '"' or '\'' in lenient mode.
+	quote byte
+
+	// state to return to after a comment.
+	commentStep func(*scanner, byte) int
+
+	// position of the last comma, for the warning of trailing commas in lenient mode.
+	comma Position
+
+	// relaxed forms accepted in lenient mode.
+	warnings []Warning
 }
 
 // reset prepares the scanner for use.
@@ -51,6 +80,28 @@ func (s *scanner) reset() {
 	s.parseState = s.parseState[0:0]
 	s.err = nil
 	s.endTop = false
+	s.line, s.column, s.newline = 1, 0, false
+	s.warnings = s.warnings[:0]
+}
+
+// next advances the position of the scanner to c, and executes the transition of c.
+func (s *scanner) next(c byte) int {
+	s.bytes++
+	s.advance(c)
+	return s.step(s, c)
+}
+
+// advance updates the line and column to c.
+func (s *scanner) advance(c byte) {
+	if s.newline {
+		s.line++
+		s.column = 0
+		s.newline = false
+	}
+	if c&0xC0 != 0x80 { // not a continuation byte of UTF-8.
+		s.column++
+	}
+	s.newline = c == '\n'
 }
 
 // eof tells the scanner that the end of input has been reached.
@@ -67,7 +118,8 @@ func (s *scanner) eof() int {
 		return scanEnd
 	}
 	if s.err == nil {
-		s.err = &SyntaxError{"unexpected end of JSON input", s.bytes}
+		s.advance(' ') // the error occurred at the position after the last byte.
+		s.err = s.syntaxError("unexpected end of JSON input")
 	}
 	return scanError
 }
@@ -96,21 +148,80 @@ func stateError(s *scanner, c byte) int {
 	return scanError
 }
 
+// inObjectKey reports whether the scanner is scanning an object key.
+func (s *scanner) inObjectKey() bool {
+	n := len(s.parseState)
+	return n > 0 && s.parseState[n-1] == parseObjectKey
+}
+
 // error records an error and switches to the error state.
 func (s *scanner) error(c byte, context string) int {
 	s.step = stateError
-	s.err = &SyntaxError{"invalid character " + quoteChar(c) + " " + context, s.bytes}
+	s.err = s.syntaxError("invalid character " + quoteChar(c) + " " + context)
 	return scanError
 }
 
+// position returns the position of the byte just scanned.
+func (s *scanner) position() Position {
+	return Position{Offset: s.bytes - 1, Line: s.line, Column: s.column}
+}
+
+// warn records a relaxed form accepted in lenient mode.
+func (s *scanner) warn(pos Position, msg string) {
+	s.warnings = append(s.warnings, Warning{Pos: pos, Msg: msg})
+}
+
+// sortedWarnings returns the warnings in the order of their positions in the input.
+// Some relaxed forms, like an unquoted key, are recognized only after the bytes after them.
+func (s *scanner) sortedWarnings() []Warning {
+	sort.SliceStable(s.warnings, func(i, j int) bool {
+		return s.warnings[i].Pos.Offset < s.warnings[j].Pos.Offset
+	})
+	return s.warnings
+}
+
+func (s *scanner) syntaxError(msg string) *SyntaxError {
+	return &SyntaxError{msg: msg, Offset: s.bytes, Line: s.line, Column: s.column}
+}
+
 // A SyntaxError is a description of a JSON syntax error.
 type SyntaxError struct {
 	msg    string // description of error
 	Offset int64  // error occurred after reading Offset bytes
+	Line   int    // line of the error, starting at 1
+	Column int    // column of the error in runes, starting at 1
 }
 
 func (e *SyntaxError) Error() string { return e.msg }
 
+// Excerpt renders the error with its position, the offending line of src,
+// and a caret under the column of the error, like:
+//
+//	3:19: invalid character '"' after array element
+//	  "sizes": [ "单人" "双人" ] # 尺寸
+//	                    ^
+func (e *SyntaxError) Excerpt(src []byte) string {
+	lines := strings.Split(string(src), "\n")
+	var line string
+	if e.Line >= 1 && e.Line <= len(lines) {
+		line = strings.TrimSuffix(lines[e.Line-1], "\r")
+	}
+	// keep tabs and indent by display width, so that the caret is aligned with the line.
+	var caret strings.Builder
+	for i, r := range []rune(line) {
+		if i >= e.Column-1 {
+			break
+		}
+		if r == '\t' {
+			caret.WriteByte('\t')
+		} else {
+			caret.WriteString(strings.Repeat(" ", width.Rune(r)))
+		}
+	}
+	return strconv.Itoa(e.Line) + ":" + strconv.Itoa(e.Column) + ": " + e.msg + "\n" +
+		line + "\n" + caret.String() + "^"
+}
+
 // quoteChar formats c as a quoted character literal
 func quoteChar(c byte) string {
 	// special cases - different from quoted strings
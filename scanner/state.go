@@ -46,6 +46,9 @@ func stateBeginValueOrEmpty(s *scanner, c byte) int {
 	if c == ']' {
 		return stateEndValue(s, c)
 	}
+	if v, ok := s.beginComment(c, stateBeginValueOrEmpty); ok {
+		return v
+	}
 	return stateBeginValue(s, c)
 }
 
@@ -65,6 +68,7 @@ func stateBeginValue(s *scanner, c byte) int {
 		return scanBeginArray
 	case '"':
 		s.step = stateInString
+		s.quote = c
 		return scanBeginLiteral
 	case '-':
 		s.step = stateNeg
@@ -81,14 +85,28 @@ func stateBeginValue(s *scanner, c byte) int {
 	case 'n': // beginning of null
 		s.step = stateN
 		return scanBeginLiteral
-	case '#': // beginning of comment
-		s.step = stateInCommentWhenBeginValue
-		return scanBeginComment
+	}
+	if v, ok := s.beginComment(c, stateBeginValue); ok {
+		return v
 	}
 	if '1' <= c && c <= '9' { // beginning of 1234.5
 		s.step = state1
 		return scanBeginLiteral
 	}
+	if s.lenient {
+		switch c {
+		case '\'':
+			s.warn(s.position(), "single-quoted string")
+			s.step = stateInString
+			s.quote = c
+			return scanBeginLiteral
+		case ']': // trailing comma
+			if n := len(s.parseState); n > 0 && s.parseState[n-1] == parseArrayValue {
+				s.warn(s.comma, "trailing comma")
+				return stateEndValue(s, c)
+			}
+		}
+	}
 	return s.error(c, "looking for beginning of value")
 }
 
@@ -102,6 +120,9 @@ func stateBeginStringOrEmpty(s *scanner, c byte) int {
 		s.parseState[n-1] = parseObjectValue
 		return stateEndValue(s, c)
 	}
+	if v, ok := s.beginComment(c, stateBeginStringOrEmpty); ok {
+		return v
+	}
 	return stateBeginString(s, c)
 }
 
@@ -112,11 +133,29 @@ func stateBeginString(s *scanner, c byte) int {
 	}
 	if c == '"' {
 		s.step = stateInString
+		s.quote = c
 		return scanBeginLiteral
 	}
-	if c == '#' {
-		s.step = stateInCommentWhenBeginString
-		return scanBeginComment
+	if v, ok := s.beginComment(c, stateBeginString); ok {
+		return v
+	}
+	if s.lenient {
+		switch {
+		case c == '\'':
+			s.warn(s.position(), "single-quoted string")
+			s.step = stateInString
+			s.quote = c
+			return scanBeginLiteral
+		case c == '}': // trailing comma
+			s.warn(s.comma, "trailing comma")
+			n := len(s.parseState)
+			s.parseState[n-1] = parseObjectValue
+			return stateEndValue(s, c)
+		case isIdentifierStart(c):
+			s.warn(s.position(), "unquoted object key")
+			s.step = stateInIdentifier
+			return scanBeginLiteral
+		}
 	}
 	return s.error(c, "looking for beginning of object key string")
 }
@@ -135,6 +174,9 @@ func stateEndValue(s *scanner, c byte) int {
 		s.step = stateEndValue
 		return scanSkipSpace
 	}
+	if v, ok := s.beginComment(c, stateEndValue); ok {
+		return v
+	}
 	ps := s.parseState[n-1]
 	switch ps {
 	case parseObjectKey:
@@ -146,6 +188,7 @@ func stateEndValue(s *scanner, c byte) int {
 		return s.error(c, "after object key")
 	case parseObjectValue:
 		if c == ',' {
+			s.comma = s.position()
 			s.parseState[n-1] = parseObjectKey
 			s.step = stateBeginString
 			return scanObjectValue
@@ -154,13 +197,10 @@ func stateEndValue(s *scanner, c byte) int {
 			s.popParseState()
 			return scanEndObject
 		}
-		if c == '#' {
-			s.step = stateInCommentWhenEndValue
-			return scanBeginComment
-		}
 		return s.error(c, "after object key:value pair")
 	case parseArrayValue:
 		if c == ',' {
+			s.comma = s.position()
 			s.step = stateBeginValue
 			return scanArrayValue
 		}
@@ -175,8 +215,11 @@ func stateEndValue(s *scanner, c byte) int {
 
 // stateEndTop is the state after finishing the top-level value,
 // such as after reading `{}` or `[1,2,3]`.
-// Only space characters should be seen now.
+// Only space characters and comments should be seen now.
 func stateEndTop(s *scanner, c byte) int {
+	if v, ok := s.beginComment(c, stateEndTop); ok {
+		return v
+	}
 	if !isSpace(c) {
 		// Complain about non-space byte on next call.
 		s.error(c, "after top-level value")
@@ -1,28 +1,77 @@
 package scanner
 
-// stateInCommentWhenBeginString is the state after reading `#`, when in begin string state.
-func stateInCommentWhenBeginString(s *scanner, c byte) int {
+// beginComment begins a comment if c is the start of one, and reports whether it is.
+// Comments are allowed wherever spaces are, state is the state to return to after the comment.
+func (s *scanner) beginComment(c byte, state func(*scanner, byte) int) (int, bool) {
+	switch c {
+	case '#':
+		s.step = stateInComment
+	case '/':
+		if !s.lenient && !s.blockComments {
+			return 0, false
+		}
+		s.step = stateCommentSlash
+	default:
+		return 0, false
+	}
+	s.commentStep = state
+	return scanBeginComment, true
+}
+
+// stateInComment is the state after reading `#` or "//".
+func stateInComment(s *scanner, c byte) int {
 	if c == '\n' {
-		s.step = stateBeginString
+		s.step = s.commentStep
 		return scanEndComment
 	}
 	return scanContinue
 }
 
-// stateInCommentWhenBeginValue is the state after reading `#`, when in begin value state.
-func stateInCommentWhenBeginValue(s *scanner, c byte) int {
-	if c == '\n' {
-		s.step = stateBeginValue
-		return scanEndComment
+// stateCommentSlash is the state after reading the first `/` of a comment.
+func stateCommentSlash(s *scanner, c byte) int {
+	if c == '*' {
+		if s.lenient {
+			s.warn(s.slashPosition(), `"/* */" comment`)
+		}
+		s.step = stateInBlockComment
+		return scanContinue
+	}
+	if c == '/' && s.lenient {
+		s.warn(s.slashPosition(), `"//" comment`)
+		s.step = stateInComment
+		return scanContinue
+	}
+	if s.lenient {
+		return s.error(c, "in comment (expecting '/' or '*')")
+	}
+	return s.error(c, "in comment (expecting '*')")
+}
+
+// slashPosition returns the position of the '/' before the byte being scanned.
+func (s *scanner) slashPosition() Position {
+	pos := s.position()
+	pos.Offset--
+	pos.Column--
+	return pos
+}
+
+// stateInBlockComment is the state after reading "/*".
+func stateInBlockComment(s *scanner, c byte) int {
+	if c == '*' {
+		s.step = stateInBlockCommentStar
 	}
 	return scanContinue
 }
 
-// stateInCommentWhenEndValue is the state after reading `#`, when in end value state.
-func stateInCommentWhenEndValue(s *scanner, c byte) int {
-	if c == '\n' {
-		s.step = stateEndValue
-		return scanSkipSpace
+// stateInBlockCommentStar is the state after reading `*` in a "/* */" comment.
+func stateInBlockCommentStar(s *scanner, c byte) int {
+	switch c {
+	case '/':
+		s.step = s.commentStep
+		return scanEndComment
+	case '*':
+		return scanContinue
 	}
+	s.step = stateInBlockComment
 	return scanContinue
 }
@@ -0,0 +1,16 @@
+package scanner
+
+// stateInIdentifier is the state after reading the first character of an unquoted object key,
+// in lenient mode.
+func stateInIdentifier(s *scanner, c byte) int {
+	if isIdentifierStart(c) || '0' <= c && c <= '9' {
+		return scanContinue
+	}
+	return stateEndValue(s, c)
+}
+
+// isIdentifierStart reports whether c can begin an unquoted object key.
+// Bytes of non-ASCII characters are all accepted.
+func isIdentifierStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_' || c == '$' || c >= 0x80
+}
@@ -1,8 +1,8 @@
 package scanner
 
-// stateInString is the state after reading `"`.
+// stateInString is the state after reading `"`, or `'` in lenient mode.
 func stateInString(s *scanner, c byte) int {
-	if c == '"' {
+	if c == s.quote {
 		s.step = stateEndValue
 		return scanContinue
 	}
@@ -22,6 +22,12 @@ func stateInStringEsc(s *scanner, c byte) int {
 	case 'b', 'f', 'n', 'r', 't', '\\', '/', '"':
 		s.step = stateInString
 		return scanContinue
+	case '\'':
+		if s.lenient {
+			s.warn(s.position(), "escaped single quote")
+			s.step = stateInString
+			return scanContinue
+		}
 	case 'u':
 		s.step = stateInStringEscU
 		return scanContinue
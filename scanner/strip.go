@@ -0,0 +1,68 @@
+package scanner
+
+import "bytes"
+
+// StripComments appends to dst the jsondoc-encoded src with comments removed,
+// so that the result is valid JSON. Spaces before comments are also removed,
+// other spaces are preserved.
+func StripComments(dst *bytes.Buffer, src []byte) error {
+	return strip(dst, src, false)
+}
+
+// StripCommentsAndPointerMarks is like StripComments, but also removes
+// the "*" prefix of object keys, which marks pointer fields in jsondoc.
+func StripCommentsAndPointerMarks(dst *bytes.Buffer, src []byte) error {
+	return strip(dst, src, true)
+}
+
+func strip(dst *bytes.Buffer, src []byte, pointerMarks bool) error {
+	origLen := dst.Len()
+	var scan scanner
+	scan.reset()
+	inComment, keyBegun := false, false
+	for _, c := range src {
+		v := scan.next(c)
+		if v == scanError {
+			break
+		}
+		if inComment {
+			if v == scanContinue {
+				continue
+			}
+			inComment = false // the newline ending the comment is preserved.
+		}
+		switch {
+		case v == scanBeginComment:
+			inComment = true
+			trimRightSpaces(dst, origLen)
+			continue
+		case keyBegun:
+			keyBegun = false
+			if c == '*' && pointerMarks {
+				continue
+			}
+		case v == scanBeginLiteral && c == '"':
+			keyBegun = scan.inObjectKey()
+		}
+		dst.WriteByte(c)
+	}
+	if scan.eof() == scanError {
+		dst.Truncate(origLen)
+		return scan.err
+	}
+	if err := Validate(dst.Bytes()[origLen:]); err != nil {
+		dst.Truncate(origLen)
+		return err
+	}
+	return nil
+}
+
+// trimRightSpaces removes spaces and tabs at the end of dst, but not before the first min bytes.
+func trimRightSpaces(dst *bytes.Buffer, min int) {
+	b := dst.Bytes()
+	n := len(b)
+	for n > min && (b[n-1] == ' ' || b[n-1] == '\t') {
+		n--
+	}
+	dst.Truncate(n)
+}
@@ -0,0 +1,156 @@
+package scanner
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// TokenKind is the kind of a Token.
+type TokenKind int
+
+const (
+	Delim   TokenKind = iota // one of { } [ ]
+	Key                      // an object key
+	String                   // a string value
+	Number                   // a number value
+	Bool                     // true or false
+	Null                     // null
+	Comment                  // a comment, from the "#" to the end of line
+)
+
+var tokenKindNames = [...]string{"Delim", "Key", "String", "Number", "Bool", "Null", "Comment"}
+
+func (k TokenKind) String() string {
+	if k >= 0 && int(k) < len(tokenKindNames) {
+		return tokenKindNames[k]
+	}
+	return "Invalid"
+}
+
+// A Position is the position of a token in the source.
+type Position struct {
+	Offset int64 // byte offset, starting at 0
+	Line   int   // line, starting at 1
+	Column int   // column in runes, starting at 1
+}
+
+// A Token is a token of jsondoc text.
+type Token struct {
+	Kind TokenKind
+	// Text is the token as it is in the source, strings and keys are quoted,
+	// comments include the "#" and exclude the newline.
+	Text string
+	// Pos is the position of the first byte of the token.
+	Pos Position
+}
+
+// A Tokenizer reads tokens of jsondoc text from an input stream.
+// Unlike json.Decoder.Token, comments are tokens, and object keys are distinguished from string values,
+// but commas and colons are not returned.
+type Tokenizer struct {
+	r    *bufio.Reader
+	scan scanner
+
+	pending *Token // the literal or comment being read
+	text    []byte // text of the pending token
+	tokens  []Token
+	err     error
+}
+
+// NewTokenizer returns a new tokenizer that reads from r.
+func NewTokenizer(r io.Reader) *Tokenizer {
+	t := &Tokenizer{r: bufio.NewReader(r)}
+	t.scan.reset()
+	return t
+}
+
+// Token returns the next token in the input stream.
+// At the end of the input stream, Token returns io.EOF.
+// If the input is not valid jsondoc text, Token returns a *SyntaxError.
+func (t *Tokenizer) Token() (Token, error) {
+	for len(t.tokens) == 0 {
+		if t.err != nil {
+			return Token{}, t.err
+		}
+		c, err := t.r.ReadByte()
+		if err == io.EOF {
+			t.end()
+		} else if err != nil {
+			t.err = err
+		} else {
+			t.step(c)
+		}
+	}
+	token := t.tokens[0]
+	t.tokens = t.tokens[1:]
+	return token, nil
+}
+
+func (t *Tokenizer) step(c byte) {
+	v := t.scan.next(c)
+	if t.pending != nil {
+		if v == scanContinue {
+			t.text = append(t.text, c)
+			return
+		}
+		t.flush()
+	}
+	switch v {
+	case scanBeginLiteral:
+		t.begin(t.literalKind(c), c)
+	case scanBeginComment:
+		t.begin(Comment, c)
+	case scanBeginObject, scanBeginArray, scanEndObject, scanEndArray:
+		t.tokens = append(t.tokens, Token{Kind: Delim, Text: string(c), Pos: t.position()})
+	case scanError:
+		t.err = t.scan.err
+	}
+}
+
+// end is called at the end of the input stream.
+func (t *Tokenizer) end() {
+	if t.scan.eof() == scanError {
+		t.err = t.scan.err
+		return
+	}
+	if t.pending != nil {
+		t.flush()
+	}
+	t.err = io.EOF
+}
+
+func (t *Tokenizer) literalKind(c byte) TokenKind {
+	if t.scan.inObjectKey() {
+		return Key
+	}
+	switch c {
+	case '"', '\'':
+		return String
+	case 't', 'f':
+		return Bool
+	case 'n':
+		return Null
+	}
+	return Number
+}
+
+func (t *Tokenizer) begin(kind TokenKind, c byte) {
+	t.pending = &Token{Kind: kind, Pos: t.position()}
+	t.text = append(t.text[:0], c)
+}
+
+func (t *Tokenizer) flush() {
+	token := *t.pending
+	token.Text = string(t.text)
+	if token.Kind == Comment {
+		token.Text = strings.TrimRight(token.Text, "\r")
+	}
+	t.tokens = append(t.tokens, token)
+	t.pending = nil
+}
+
+// position returns the position of the byte just scanned.
+func (t *Tokenizer) position() Position {
+	return t.scan.position()
+}
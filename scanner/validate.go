@@ -0,0 +1,26 @@
+package scanner
+
+// Validate checks whether src is a valid JSON document, comments included.
+func Validate(src []byte) error {
+	return validate(src, false)
+}
+
+// ValidateLenient is like Validate, but also accepts the relaxed forms of JSON5:
+// unquoted object keys, single-quoted strings, trailing commas, and "//" and "/* */" comments.
+func ValidateLenient(src []byte) error {
+	return validate(src, true)
+}
+
+func validate(src []byte, lenient bool) error {
+	scan := scanner{lenient: lenient}
+	scan.reset()
+	for _, c := range src {
+		if scan.next(c) == scanError {
+			return scan.err
+		}
+	}
+	if scan.eof() == scanError {
+		return scan.err
+	}
+	return nil
+}
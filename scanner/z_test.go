@@ -0,0 +1,144 @@
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+func ExampleSyntaxError_Excerpt() {
+	src := []byte(`{
+  "name": "沙发", # 名称
+  "sizes": [ "单人" "双人" ] # 尺寸
+}`)
+	err := Validate(src).(*SyntaxError)
+	fmt.Println(err.Line, err.Column, err.Offset)
+	fmt.Println(err.Excerpt(src))
+
+	src = []byte("{\n")
+	fmt.Println(Validate(src).(*SyntaxError).Excerpt(src))
+
+	// Output:
+	// 3 19 54
+	// 3:19: invalid character '"' after array element
+	//   "sizes": [ "单人" "双人" ] # 尺寸
+	//                     ^
+	// 2:1: unexpected end of JSON input
+	//
+	// ^
+}
+
+func ExampleTokenizer() {
+	t := NewTokenizer(strings.NewReader(`{ # 沙发
+  "name": "沙发", # 名称
+  "sizes": [1, 2.5],
+  "*owner": null
+}`))
+	for {
+		token, err := t.Token()
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+		fmt.Printf("%d:%d %s %s\n", token.Pos.Line, token.Pos.Column, token.Kind, token.Text)
+	}
+
+	// Output:
+	// 1:1 Delim {
+	// 1:3 Comment # 沙发
+	// 2:3 Key "name"
+	// 2:11 String "沙发"
+	// 2:17 Comment # 名称
+	// 3:3 Key "sizes"
+	// 3:12 Delim [
+	// 3:13 Number 1
+	// 3:16 Number 2.5
+	// 3:19 Delim ]
+	// 4:3 Key "*owner"
+	// 4:13 Null null
+	// 5:1 Delim }
+	// EOF
+}
+
+func ExampleIndent() {
+	src := []byte(`# 商品
+{"name": "沙发", # 名称
+"sizes": [ # 尺寸
+  # 单位为厘米
+  180, 200 # 常用
+],
+"tags": [] # 标签
+# 其他字段待定
+}`)
+	var buf bytes.Buffer
+	err := Indent(&buf, src, "", "  ")
+	fmt.Println(buf.String(), err)
+
+	// Output:
+	// # 商品
+	// {
+	//   "name": "沙发",	 # 名称
+	//   "sizes": [	 # 尺寸
+	//     # 单位为厘米
+	//     180,
+	//     200	 # 常用
+	//   ],
+	//   "tags": [
+	//   ]	 # 标签
+	//   # 其他字段待定
+	// } <nil>
+}
+
+func ExampleIndentLenient() {
+	src := []byte(`{
+  name: '沙发', // 名称
+  'sizes': [180, 200,], /* 单位为
+                           **厘米** */
+}`)
+	var buf bytes.Buffer
+	warnings, err := IndentLenient(&buf, src, "", "  ")
+	fmt.Println(buf.String(), err)
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+
+	// Output:
+	// {
+	//   "name": "沙发",	 # 名称
+	//   "sizes": [
+	//     180,
+	//     200
+	//   ]	 # 单位为 **厘米**
+	// } <nil>
+	// 2:3: unquoted object key
+	// 2:9: single-quoted string
+	// 2:15: "//" comment
+	// 3:3: single-quoted string
+	// 3:21: trailing comma
+	// 3:23: trailing comma
+	// 3:25: "/* */" comment
+}
+
+func ExampleCompactComments() {
+	src := []byte(`{
+  "name": "沙发", # 名称
+  "sizes": [180, 200] # 单位为厘米
+}`)
+	var block bytes.Buffer
+	for _, mode := range []CommentMode{KeepComments, DropComments, BlockComments} {
+		block.Reset()
+		err := CompactComments(&block, src, false, mode)
+		fmt.Println(block.String(), err)
+	}
+	var buf bytes.Buffer
+	err := CompactComments(&buf, block.Bytes(), false, DropComments)
+	fmt.Println(buf.String(), err)
+
+	// Output:
+	// {"name":"沙发",# 名称
+	// "sizes":[180,200]# 单位为厘米
+	// } <nil>
+	// {"name":"沙发","sizes":[180,200]} <nil>
+	// {"name":"沙发",/* 名称 */"sizes":[180,200]/* 单位为厘米 */} <nil>
+	// {"name":"沙发","sizes":[180,200]} <nil>
+}
@@ -0,0 +1,30 @@
+package jsondoc
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/lovego/jsondoc/schema"
+)
+
+// Schema returns the JSON Schema (draft 2020-12) of the type of v.
+// Field names, embedding rules and the "string" option are resolved the same way as MarshalIndent,
+// comments become "description", and named struct types become "$defs" referenced by "$ref".
+// Pointers also allow null, like the values where recursion stops in MarshalIndent.
+// The "required" and "format" tags set the required properties and the format of a field;
+// without a "required" tag, fields without "omitempty" are required.
+func Schema(v interface{}) ([]byte, error) {
+	return SchemaWithOptions(v, Options{})
+}
+
+// SchemaWithOptions is like Schema but uses opts to filter struct fields.
+func SchemaWithOptions(v interface{}, opts Options) ([]byte, error) {
+	if v == nil {
+		return nil, errors.New("jsondoc: Schema(nil)")
+	}
+	s, err := schema.Generate(reflect.TypeOf(v), opts.encoderOptions())
+	if err != nil {
+		return nil, err
+	}
+	return schema.Marshal(s)
+}
@@ -0,0 +1,233 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lovego/jsondoc/encoder/funcs"
+	"github.com/lovego/jsondoc/encoder/types"
+	"github.com/lovego/struct_tag"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	numberType        = reflect.TypeOf(json.Number(""))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Generate returns the JSON Schema of type t.
+// Struct fields are filtered by opts as the encoder does.
+// Named struct types are defined in "$defs" and referenced by "$ref",
+// a reference to t itself is "#".
+func Generate(t reflect.Type, opts types.Options) (*Schema, error) {
+	g := newGenerator(opts, "#/$defs/")
+	t = indirect(t)
+	if t.Kind() == reflect.Struct && t.Name() != "" {
+		g.names[t] = ""
+	}
+	s := g.typeSchema(t, true)
+	if g.err != nil {
+		return nil, g.err
+	}
+	s.Schema = Draft
+	if len(g.defs) > 0 {
+		s.Defs = g.defs
+	}
+	return s, nil
+}
+
+// A generator generates schemas of Go types.
+type generator struct {
+	opts      types.Options
+	refPrefix string
+	defs      map[string]*Schema
+	names     map[reflect.Type]string
+	err       error
+}
+
+func newGenerator(opts types.Options, refPrefix string) *generator {
+	return &generator{
+		opts:      opts,
+		refPrefix: refPrefix,
+		defs:      make(map[string]*Schema),
+		names:     make(map[reflect.Type]string),
+	}
+}
+
+// A declaration is a named type to declare, by generators of other languages.
+type declaration struct {
+	name string
+	typ  reflect.Type
+}
+
+func (g *generator) setError(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+// typeSchema returns the schema of t.
+// If inline is false, named struct types are referenced by "$ref".
+func (g *generator) typeSchema(t reflect.Type, inline bool) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if funcs.IsMarshaler(t) {
+		// Anything can be produced by MarshalJSON.
+		return &Schema{}
+	}
+	if funcs.IsTextMarshaler(t) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		if t == numberType {
+			return &Schema{Type: "number"}
+		}
+		return &Schema{Type: "string"}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if !inline && t.Name() != "" {
+			return g.ref(t)
+		}
+		return g.structSchema(t)
+	case reflect.Map:
+		if !isValidMapKey(t.Key()) {
+			break
+		}
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem(), false)}
+	case reflect.Slice:
+		if isByteSlice(t) {
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem(), false)}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem(), false)}
+	case reflect.Ptr:
+		return nullable(g.typeSchema(t.Elem(), inline))
+	}
+	g.setError(&funcs.UnsupportedTypeError{Type: t})
+	return &Schema{}
+}
+
+// ref returns a reference to the definition of named struct type t.
+func (g *generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = g.defName(t)
+		g.names[t] = name // record name first to deal with recursive types.
+		g.defs[name] = g.structSchema(t)
+	} else if name == "" {
+		return &Schema{Ref: "#"}
+	}
+	return &Schema{Ref: g.refPrefix + name}
+}
+
+// defName returns a unique definition name for type t.
+func (g *generator) defName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i > 0 { // instantiated generic type
+		name = name[:i]
+	}
+	if _, ok := g.defs[name]; !ok {
+		return name
+	}
+	for i := 2; ; i++ {
+		if _, ok := g.defs[name+strconv.Itoa(i)]; !ok {
+			return name + strconv.Itoa(i)
+		}
+	}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object"}
+	for _, f := range funcs.TypeFields(t, g.opts) {
+		s.Properties = append(s.Properties, Property{Name: f.Name, Schema: g.fieldSchema(f)})
+		if isRequired(f) {
+			s.Required = append(s.Required, f.Name)
+		}
+	}
+	return s
+}
+
+func (g *generator) fieldSchema(f funcs.Field) *Schema {
+	var s *Schema
+	if f.Quoted {
+		s = &Schema{Type: "string"}
+	} else {
+		s = g.typeSchema(f.Type, false)
+	}
+	if f.Pointer {
+		s = nullable(s)
+	}
+	s.Description = f.Comment
+	s.Deprecated = f.Deprecated
+	if format := strings.TrimSpace(struct_tag.Get(string(f.Tag), "format")); format != "" {
+		s.Format = format
+	}
+	return s
+}
+
+// nullable returns a schema which allows null besides s, for pointer types.
+func nullable(s *Schema) *Schema {
+	if s.Ref == "" && s.Type == "" && len(s.AnyOf) == 0 {
+		return s // s allows anything.
+	}
+	if isNullable(s) {
+		return s
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+// isNullable reports whether s is returned by nullable.
+func isNullable(s *Schema) bool {
+	return len(s.AnyOf) == 2 && s.AnyOf[1].Type == "null"
+}
+
+// isRequired reports whether a field is required.
+// It's decided by the "required" tag if present, otherwise fields without "omitempty" are required.
+func isRequired(f funcs.Field) bool {
+	if required, ok := struct_tag.Lookup(string(f.Tag), "required"); ok {
+		if required = strings.TrimSpace(required); required == "" {
+			return true
+		}
+		b, _ := strconv.ParseBool(required)
+		return b
+	}
+	return !f.OmitEmpty
+}
+
+func isValidMapKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return t.Implements(textMarshalerType)
+}
+
+// isByteSlice reports whether t is encoded as a base64 string.
+func isByteSlice(t reflect.Type) bool {
+	return t.Elem().Kind() == reflect.Uint8 && !funcs.IsMarshaler(t.Elem()) && !funcs.IsTextMarshaler(t.Elem())
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,142 @@
+package schema
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+
+	"github.com/lovego/jsondoc/ast"
+	"github.com/lovego/jsondoc/encoder"
+	"github.com/lovego/jsondoc/encoder/funcs"
+	"github.com/lovego/jsondoc/encoder/types"
+)
+
+// MarkdownTable appends to buf a Markdown table of the fields of v,
+// with columns for JSON path, type, required, example and description.
+// Nested objects are flattened into paths like "data.items[].sku",
+// map values are like "data.prices.*", and recursive types are not expanded again.
+// Examples are the values rendered by the encoder.
+func MarkdownTable(buf *bytes.Buffer, v interface{}, opts types.Options) error {
+	opts.OmitComments = true
+	opts.OmitPointerMark = true
+	b, err := encoder.MarshalWithOptions(v, opts)
+	if err != nil {
+		return err
+	}
+	example, err := ast.Parse(b)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("| Path | Type | Required | Example | Description |\n")
+	buf.WriteString("| --- | --- | --- | --- | --- |\n")
+	if v == nil {
+		return nil
+	}
+	t := &markdownTable{buf: buf, generator: newGenerator(opts, "")}
+	t.value(reflect.TypeOf(v), "", example, nil)
+	return t.err
+}
+
+type markdownTable struct {
+	buf *bytes.Buffer
+	*generator
+}
+
+// value writes the rows of the descendants of a value of type typ.
+// ancestors are the struct types in upper layers, to stop at recursive types.
+func (t *markdownTable) value(typ reflect.Type, path string, example *ast.Node, ancestors []reflect.Type) {
+	if funcs.IsMarshaler(typ) || funcs.IsTextMarshaler(typ) {
+		return
+	}
+	switch typ.Kind() {
+	case reflect.Ptr:
+		t.value(typ.Elem(), path, example, ancestors)
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && isByteSlice(typ) {
+			return
+		}
+		t.value(typ.Elem(), path+types.ArrayElemPath, firstChild(example), ancestors)
+	case reflect.Map:
+		t.value(typ.Elem(), path+types.MapElemPath, firstChild(example), ancestors)
+	case reflect.Struct:
+		for _, ancestor := range ancestors {
+			if ancestor == typ {
+				return
+			}
+		}
+		ancestors = append(ancestors, typ)
+		for _, f := range funcs.TypeFields(typ, t.opts) {
+			fieldPath := f.Name
+			if path != "" {
+				fieldPath = path + "." + f.Name
+			}
+			fieldExample := childByKey(example, f.Name)
+			t.row(fieldPath, f, fieldExample)
+			if !f.Quoted {
+				t.value(f.Type, fieldPath, fieldExample, ancestors)
+			}
+		}
+	}
+}
+
+func (t *markdownTable) row(path string, f funcs.Field, example *ast.Node) {
+	var s *Schema
+	if f.Quoted {
+		s = &Schema{Type: "string"}
+	} else {
+		s = t.typeSchema(f.Type, false)
+	}
+	required := "no"
+	if isRequired(f) {
+		required = "yes"
+	}
+	exampleText := ""
+	if example != nil && example.IsScalar() {
+		exampleText = "`" + example.Value + "`"
+	}
+	t.buf.WriteString("| `" + path + "` | " + typeName(s) + " | " + required + " | " +
+		markdownEscape(exampleText) + " | " + markdownEscape(f.Comment) + " |\n")
+}
+
+// typeName returns a short name of the type of schema s, like "string", "object[]" or "map<string, integer>".
+func typeName(s *Schema) string {
+	switch {
+	case isNullable(s):
+		return typeName(s.AnyOf[0])
+	case s.Ref != "":
+		return "object"
+	case s.Type == "":
+		return "any"
+	case s.Type == "array":
+		return typeName(s.Items) + "[]"
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return "map<string, " + typeName(s.AdditionalProperties) + ">"
+	case s.Format != "":
+		return s.Type + " (" + s.Format + ")"
+	}
+	return s.Type
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func firstChild(n *ast.Node) *ast.Node {
+	if n == nil || len(n.Children) == 0 {
+		return nil
+	}
+	return n.Children[0]
+}
+
+func childByKey(n *ast.Node, key string) *ast.Node {
+	if n == nil || n.Kind != ast.Object {
+		return nil
+	}
+	for _, child := range n.Children {
+		if child.Key == key {
+			return child
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/lovego/jsondoc/encoder"
+	"github.com/lovego/jsondoc/encoder/types"
+)
+
+// Components generates the "components.schemas" section of an OpenAPI 3.0 or 3.1 document
+// for a set of registered Go types.
+type Components struct {
+	version string
+	opts    types.Options
+	values  []registeredValue
+}
+
+type registeredValue struct {
+	name  string
+	value interface{}
+}
+
+// NewComponents returns Components for OpenAPI version, which is "3.0" or "3.1" (the default).
+// Struct fields are filtered by opts as the encoder does.
+func NewComponents(version string, opts types.Options) *Components {
+	return &Components{version: version, opts: opts}
+}
+
+// Register registers the type of v as a schema named name.
+// If name is empty, the type name is used.
+// The value rendered by the encoder becomes the example of the schema.
+func (c *Components) Register(name string, v interface{}) {
+	c.values = append(c.values, registeredValue{name: name, value: v})
+}
+
+// Schemas returns the schemas of all registered types, and the named struct types they reference.
+func (c *Components) Schemas() (map[string]*Schema, error) {
+	g := newGenerator(c.opts, "#/components/schemas/")
+	// name all the registered types first, so that references to them use the registered names.
+	names := make([]string, len(c.values))
+	for i, rv := range c.values {
+		if rv.value == nil {
+			return nil, errors.New("jsondoc: Register(nil)")
+		}
+		t := indirect(reflect.TypeOf(rv.value))
+		if names[i] = rv.name; names[i] == "" {
+			if names[i] = g.defName(t); names[i] == "" {
+				return nil, errors.New("jsondoc: Register an unnamed type without a name: " + t.String())
+			}
+		}
+		if t.Kind() == reflect.Struct {
+			if _, ok := g.names[t]; !ok {
+				g.names[t] = names[i]
+			}
+		}
+		g.defs[names[i]] = nil
+	}
+
+	for i, rv := range c.values {
+		t := indirect(reflect.TypeOf(rv.value))
+		var s *Schema
+		if t.Kind() == reflect.Struct {
+			s = g.structSchema(t)
+		} else {
+			s = g.typeSchema(t, false)
+		}
+		if g.err != nil {
+			return nil, g.err
+		}
+		example, err := c.example(rv.value)
+		if err != nil {
+			return nil, err
+		}
+		if c.version == "3.0" {
+			s.Example = example
+		} else {
+			s.Examples = []interface{}{example}
+		}
+		g.defs[names[i]] = s
+	}
+	if c.version == "3.0" {
+		for _, s := range g.defs {
+			toOpenAPI30(s)
+		}
+	}
+	return g.defs, nil
+}
+
+// MarshalJSON encodes the components as `{"components":{"schemas":{...}}}`.
+func (c *Components) MarshalJSON() ([]byte, error) {
+	schemas, err := c.Schemas()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"components":{"schemas":`)
+	if err := encode(&buf, schemas); err != nil {
+		return nil, err
+	}
+	buf.WriteString(`}}`)
+	return buf.Bytes(), nil
+}
+
+// example renders v by the encoder, without comments and pointer marks.
+func (c *Components) example(v interface{}) (json.RawMessage, error) {
+	opts := c.opts
+	opts.OmitComments = true
+	opts.OmitPointerMark = true
+	return encoder.MarshalWithOptions(v, opts)
+}
+
+// toOpenAPI30 converts a JSON Schema to an OpenAPI 3.0 Schema Object.
+func toOpenAPI30(s *Schema) {
+	if s == nil {
+		return
+	}
+	// The "null" type doesn't exist in OpenAPI 3.0.
+	if isNullable(s) {
+		inner := s.AnyOf[0]
+		if inner.Ref != "" {
+			s.AllOf, s.AnyOf = []*Schema{inner}, nil
+		} else {
+			merged := *inner
+			merged.Description, merged.Deprecated = s.Description, s.Deprecated
+			if s.Format != "" {
+				merged.Format = s.Format
+			}
+			*s = merged
+		}
+		s.Nullable = true
+	}
+	// Siblings of "$ref" are ignored in OpenAPI 3.0.
+	if s.Ref != "" && (s.Description != "" || s.Deprecated || s.Format != "") {
+		s.AllOf = []*Schema{{Ref: s.Ref}}
+		s.Ref = ""
+	}
+	if s.ContentEncoding == "base64" {
+		s.Format, s.ContentEncoding = "byte", ""
+	}
+	for _, p := range s.Properties {
+		toOpenAPI30(p.Schema)
+	}
+	toOpenAPI30(s.Items)
+	toOpenAPI30(s.AdditionalProperties)
+}
@@ -0,0 +1,288 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lovego/jsondoc/encoder/funcs"
+	"github.com/lovego/jsondoc/encoder/types"
+	"github.com/lovego/struct_tag"
+)
+
+// Proto appends to buf a proto3 file with messages for the types of values, in package pkg.
+// Named struct types, including the ones referenced by fields, become top-level messages,
+// and anonymous struct types become nested messages. Slices become repeated fields,
+// maps become map<> fields, and comments become "//" comments.
+// Field numbers are set by the "proto" tag, like `proto:"3"`. Without it, a field number
+// is the position of the field in the struct fields before filtering by opts, so it's stable
+// across api versions and views, but not when fields are added or reordered before it.
+// Duplicate field numbers and field names in a message are errors.
+func Proto(buf *bytes.Buffer, pkg string, opts types.Options, values ...interface{}) error {
+	g := &protoGenerator{generator: newGenerator(opts, ""), imports: map[string]bool{}}
+	for _, v := range values {
+		if v == nil {
+			return errors.New("jsondoc: Proto(nil)")
+		}
+		t := indirect(reflect.TypeOf(v))
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return errors.New("jsondoc: Proto of a type other than named struct: " + t.String())
+		}
+		g.messageName(t)
+	}
+
+	var body bytes.Buffer
+	for i := 0; i < len(g.queue); i++ {
+		body.WriteByte('\n')
+		g.writeMessage(&body, g.queue[i].name, g.queue[i].typ, 0)
+		if g.err != nil {
+			return g.err
+		}
+	}
+
+	buf.WriteString("syntax = \"proto3\";\n")
+	if pkg != "" {
+		buf.WriteString("\npackage " + pkg + ";\n")
+	}
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		buf.WriteByte('\n')
+		for _, imp := range imports {
+			buf.WriteString("import \"" + imp + "\";\n")
+		}
+	}
+	buf.Write(body.Bytes())
+	return nil
+}
+
+type protoGenerator struct {
+	*generator
+	queue   []declaration // messages to write
+	imports map[string]bool
+}
+
+// messageName returns the message name of named struct type t, and queues its declaration.
+func (g *protoGenerator) messageName(t reflect.Type) string {
+	name, ok := g.names[t]
+	if !ok {
+		name = g.defName(t)
+		g.names[t] = name
+		g.defs[name] = nil
+		g.queue = append(g.queue, declaration{name: name, typ: t})
+	}
+	return name
+}
+
+func (g *protoGenerator) writeMessage(buf *bytes.Buffer, name string, t reflect.Type, depth int) {
+	indent := strings.Repeat("  ", depth)
+	buf.WriteString(indent + "message " + name + " {\n")
+
+	numbers := g.fieldNumbers(name, t)
+	names := make(map[string]string) // field names in proto to names in JSON
+	var nested bytes.Buffer
+	for _, f := range funcs.TypeFields(t, g.opts) {
+		var typ string
+		if f.Quoted {
+			typ = "string"
+		} else {
+			typ = g.protoType(f.Type, f.Name, &nested, depth+1)
+		}
+		if f.Pointer && isProtoScalar(typ) {
+			typ = "optional " + typ
+		}
+		if f.Comment != "" {
+			buf.WriteString(indent + "  // " + f.Comment + "\n")
+		}
+		fieldName := snakeCase(f.Name)
+		if other, ok := names[fieldName]; ok {
+			g.setError(errors.New("jsondoc: proto fields " + other + " and " + f.Name +
+				" of message " + name + " are both named " + fieldName))
+		}
+		names[fieldName] = f.Name
+		buf.WriteString(indent + "  " + typ + " " + fieldName + " = " + strconv.Itoa(numbers[f.Name]))
+		var options []string
+		if protoJSONName(fieldName) != f.Name {
+			options = append(options, "json_name = "+quote(f.Name))
+		}
+		if f.Deprecated {
+			options = append(options, "deprecated = true")
+		}
+		if len(options) > 0 {
+			buf.WriteString(" [" + strings.Join(options, ", ") + "]")
+		}
+		buf.WriteString(";\n")
+	}
+	if nested.Len() > 0 {
+		buf.WriteByte('\n')
+		buf.Write(nested.Bytes())
+	}
+	buf.WriteString(indent + "}\n")
+}
+
+// fieldNumbers returns the field numbers of struct type t of message name, by JSON names of fields.
+func (g *protoGenerator) fieldNumbers(name string, t reflect.Type) map[string]int {
+	numbers := make(map[string]int)
+	fields := make(map[int]string) // field numbers to names
+	for i, f := range funcs.TypeFields(t, types.Options{}) {
+		number := i + 1
+		if tag := strings.TrimSpace(struct_tag.Get(string(f.Tag), "proto")); tag != "" {
+			n, err := strconv.Atoi(tag)
+			if err != nil || n < 1 || n > 536870911 || n >= 19000 && n <= 19999 {
+				g.setError(errors.New("jsondoc: invalid proto field number of " + name + "." + f.Name + ": " + tag))
+			}
+			number = n
+		}
+		if other, ok := fields[number]; ok {
+			g.setError(errors.New("jsondoc: proto field number " + strconv.Itoa(number) +
+				" of message " + name + " is used by both " + other + " and " + f.Name))
+		}
+		fields[number] = f.Name
+		numbers[f.Name] = number
+	}
+	return numbers
+}
+
+// protoType returns the proto type of Go type t for field fieldName,
+// anonymous struct types are written to nested as nested messages.
+func (g *protoGenerator) protoType(t reflect.Type, fieldName string, nested *bytes.Buffer, depth int) string {
+	if t == timeType {
+		g.imports["google/protobuf/timestamp.proto"] = true
+		return "google.protobuf.Timestamp"
+	}
+	if funcs.IsMarshaler(t) {
+		g.imports["google/protobuf/struct.proto"] = true
+		return "google.protobuf.Value"
+	}
+	if funcs.IsTextMarshaler(t) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int64:
+		return "int64"
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return "int32"
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return "uint64"
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "uint32"
+	case reflect.Float32:
+		return "float"
+	case reflect.Float64:
+		return "double"
+	case reflect.String:
+		return "string"
+	case reflect.Interface:
+		g.imports["google/protobuf/struct.proto"] = true
+		return "google.protobuf.Value"
+	case reflect.Struct:
+		if t.Name() != "" {
+			return g.messageName(t)
+		}
+		name := camelCase(fieldName)
+		if nested.Len() > 0 {
+			nested.WriteByte('\n')
+		}
+		g.writeMessage(nested, name, t, depth)
+		return name
+	case reflect.Map:
+		if !isValidMapKey(t.Key()) {
+			break
+		}
+		key := "string"
+		if !funcs.IsTextMarshaler(t.Key()) && t.Key().Kind() != reflect.String {
+			key = g.protoType(t.Key(), fieldName, nested, depth)
+		}
+		value := g.protoType(t.Elem(), fieldName, nested, depth)
+		if strings.HasPrefix(value, "repeated ") || strings.HasPrefix(value, "map<") {
+			g.setError(errors.New("jsondoc: proto3 map values can't be repeated or maps: " + t.String()))
+		}
+		return "map<" + key + ", " + value + ">"
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && isByteSlice(t) {
+			return "bytes"
+		}
+		elem := g.protoType(t.Elem(), fieldName, nested, depth)
+		if strings.HasPrefix(elem, "repeated ") || strings.HasPrefix(elem, "map<") {
+			g.setError(errors.New("jsondoc: proto3 repeated fields can't be repeated or maps: " + t.String()))
+		}
+		return "repeated " + elem
+	case reflect.Ptr:
+		return g.protoType(t.Elem(), fieldName, nested, depth)
+	}
+	g.setError(&funcs.UnsupportedTypeError{Type: t})
+	return "bytes"
+}
+
+func isProtoScalar(typ string) bool {
+	switch typ {
+	case "bool", "int32", "int64", "uint32", "uint64", "float", "double", "string", "bytes":
+		return true
+	}
+	return false
+}
+
+// snakeCase converts a JSON name like "userName", "UserName" or "user-name" to "user_name".
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, c := range runes {
+		switch {
+		case unicode.IsUpper(c):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(c))
+		case c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)):
+			b.WriteRune(c)
+		default:
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+		}
+	}
+	s := strings.Trim(b.String(), "_")
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "field_" + s
+	}
+	return s
+}
+
+// camelCase converts a JSON name like "user_name" to "UserName", for nested message names.
+func camelCase(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(snakeCase(name), "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// protoJSONName returns the JSON name protoc generates for a field name.
+func protoJSONName(fieldName string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range fieldName {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+// Package schema generates schemas of Go types, resolving struct fields,
+// comments and tags by the same rules as the jsondoc encoder.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// A Schema is a JSON Schema, which is also an OpenAPI Schema Object.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"` // used by OpenAPI 3.0 only
+	Properties           Properties         `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// Example is used by OpenAPI 3.0, and Examples is used by JSON Schema and OpenAPI 3.1.
+	Example  interface{}   `json:"example,omitempty"`
+	Examples []interface{} `json:"examples,omitempty"`
+}
+
+// Properties are the properties of an object schema, in the order of struct fields.
+type Properties []Property
+
+// A Property is a named property of an object schema.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// MarshalJSON encodes properties as a JSON object, keeping their order.
+func (ps Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, p := range ps {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encode(&buf, p.Name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encode(&buf, p.Schema); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Marshal returns the indented JSON encoding of v, without escaping HTML characters.
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, v interface{}) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // remove the newline appended by Encode
+	return nil
+}
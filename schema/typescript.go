@@ -0,0 +1,189 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/lovego/jsondoc/encoder/funcs"
+	"github.com/lovego/jsondoc/encoder/types"
+)
+
+// TypeScript appends to buf TypeScript declarations of the types of values.
+// Named struct types, including the ones referenced by fields, become "export interface" declarations,
+// with comments as JSDoc. Fields with "omitempty" are optional, pointers are "| null",
+// maps are "Record<string, T>", and time.Time is string.
+// Other named types become "export type" declarations.
+func TypeScript(buf *bytes.Buffer, opts types.Options, values ...interface{}) error {
+	g := &tsGenerator{generator: newGenerator(opts, "")}
+	for _, v := range values {
+		if v == nil {
+			return errors.New("jsondoc: TypeScript(nil)")
+		}
+		t := indirect(reflect.TypeOf(v))
+		if t.Name() == "" {
+			return errors.New("jsondoc: TypeScript of an unnamed type: " + t.String())
+		}
+		if t.Kind() == reflect.Struct {
+			g.interfaceName(t)
+		} else {
+			name := g.defName(t)
+			g.defs[name] = nil
+			g.queue = append(g.queue, declaration{name: name, typ: t})
+		}
+	}
+
+	for i := 0; i < len(g.queue); i++ {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		d := g.queue[i]
+		if d.typ.Kind() == reflect.Struct {
+			buf.WriteString("export interface " + d.name + " ")
+			g.writeStruct(buf, d.typ, 0)
+			buf.WriteByte('\n')
+		} else {
+			buf.WriteString("export type " + d.name + " = " + g.tsType(d.typ, 0) + ";\n")
+		}
+		if g.err != nil {
+			return g.err
+		}
+	}
+	return nil
+}
+
+type tsGenerator struct {
+	*generator
+	queue []declaration // declarations to write
+}
+
+// interfaceName returns the interface name of named struct type t, and queues its declaration.
+func (g *tsGenerator) interfaceName(t reflect.Type) string {
+	name, ok := g.names[t]
+	if !ok {
+		name = g.defName(t)
+		g.names[t] = name
+		g.defs[name] = nil
+		g.queue = append(g.queue, declaration{name: name, typ: t})
+	}
+	return name
+}
+
+// tsType returns the TypeScript type of Go type t.
+func (g *tsGenerator) tsType(t reflect.Type, depth int) string {
+	if t == timeType {
+		return "string"
+	}
+	if funcs.IsMarshaler(t) {
+		return "unknown"
+	}
+	if funcs.IsTextMarshaler(t) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		if t == numberType {
+			return "number"
+		}
+		return "string"
+	case reflect.Interface:
+		return "any"
+	case reflect.Struct:
+		if t.Name() != "" {
+			return g.interfaceName(t)
+		}
+		var buf bytes.Buffer
+		g.writeStruct(&buf, t, depth)
+		return buf.String()
+	case reflect.Map:
+		if isValidMapKey(t.Key()) {
+			return "Record<string, " + g.tsType(t.Elem(), depth) + ">"
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && isByteSlice(t) {
+			return "string"
+		}
+		elem := g.tsType(t.Elem(), depth)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Ptr:
+		return g.tsType(t.Elem(), depth) + " | null"
+	}
+	g.setError(&funcs.UnsupportedTypeError{Type: t})
+	return "never"
+}
+
+// writeStruct writes the object type literal of struct type t.
+func (g *tsGenerator) writeStruct(buf *bytes.Buffer, t reflect.Type, depth int) {
+	fields := funcs.TypeFields(t, g.opts)
+	if len(fields) == 0 {
+		buf.WriteString("{}")
+		return
+	}
+	buf.WriteString("{\n")
+	indent := strings.Repeat("  ", depth+1)
+	for _, f := range fields {
+		if f.Description != "" || f.Deprecated {
+			buf.WriteString(indent + "/**")
+			if f.Description != "" {
+				buf.WriteString(" " + jsDocEscape(f.Description))
+			}
+			if f.Deprecated {
+				buf.WriteString(" @deprecated")
+				if f.Deprecation != "" {
+					buf.WriteString(" " + jsDocEscape(f.Deprecation))
+				}
+			}
+			buf.WriteString(" */\n")
+		}
+		buf.WriteString(indent + tsKey(f.Name))
+		if f.OmitEmpty {
+			buf.WriteByte('?')
+		}
+		buf.WriteString(": ")
+		if f.Quoted {
+			buf.WriteString("string")
+		} else {
+			typ := g.tsType(f.Type, depth+1)
+			if f.Pointer {
+				typ += " | null"
+			}
+			buf.WriteString(typ)
+		}
+		buf.WriteString(";\n")
+	}
+	buf.WriteString(strings.Repeat("  ", depth) + "}")
+}
+
+// tsKey returns name as a TypeScript property name, quoting it if it's not an identifier.
+func tsKey(name string) string {
+	for i, c := range name {
+		if !(c == '_' || c == '$' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+func quote(s string) string {
+	var buf bytes.Buffer
+	encode(&buf, s)
+	return buf.String()
+}
+
+// jsDocEscape escapes the "*/" in s, which ends a JSDoc comment.
+func jsDocEscape(s string) string {
+	return strings.Replace(s, "*/", "*\\/", -1)
+}
@@ -0,0 +1,23 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/scanner"
+)
+
+// Strip converts jsondoc text, like the output of MarshalIndent, back into valid JSON,
+// by removing comments, and the "*" prefix of pointer fields if pointerMarks is true.
+func Strip(src []byte, pointerMarks bool) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if pointerMarks {
+		err = scanner.StripCommentsAndPointerMarks(&buf, src)
+	} else {
+		err = scanner.StripComments(&buf, src)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
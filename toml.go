@@ -0,0 +1,30 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/render"
+)
+
+// MarshalTOML is like MarshalIndent but produces TOML, for config templates.
+// v must be encoded as an object. Nested structs become [table] sections,
+// slices of structs become [[array]] tables, and comments become "# " lines above keys.
+// Nil values are commented out, or dropped inside inline arrays and tables.
+// Other slices are written inline, so comments of fields nested in them are lost.
+// The "*" prefix of pointer fields is omitted.
+func MarshalTOML(v interface{}) ([]byte, error) {
+	return MarshalTOMLWithOptions(v, Options{})
+}
+
+// MarshalTOMLWithOptions is like MarshalTOML but uses opts to control the output.
+func MarshalTOMLWithOptions(v interface{}, opts Options) ([]byte, error) {
+	n, err := parseMarshaled(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := render.TOML(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,24 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/render"
+)
+
+// MarshalTree is like MarshalIndent but produces a compact plain-text tree
+// for terminals and logs, with a type after each key and the comments aligned in a column.
+func MarshalTree(v interface{}) ([]byte, error) {
+	return MarshalTreeWithOptions(v, Options{})
+}
+
+// MarshalTreeWithOptions is like MarshalTree but uses opts to control the output.
+func MarshalTreeWithOptions(v interface{}, opts Options) ([]byte, error) {
+	n, err := parseMarshaled(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	render.Tree(&buf, n)
+	return buf.Bytes(), nil
+}
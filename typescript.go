@@ -0,0 +1,24 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/schema"
+)
+
+// TypeScript returns TypeScript declarations of the types of values, which must be named.
+// Named struct types become "export interface" declarations with comments as JSDoc,
+// using the same field names as MarshalIndent. Fields with "omitempty" are optional,
+// pointers are "| null", and maps are "Record<string, T>".
+func TypeScript(values ...interface{}) ([]byte, error) {
+	return TypeScriptWithOptions(Options{}, values...)
+}
+
+// TypeScriptWithOptions is like TypeScript but uses opts to filter struct fields.
+func TypeScriptWithOptions(opts Options, values ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := schema.TypeScript(&buf, opts.encoderOptions(), values...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
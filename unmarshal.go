@@ -0,0 +1,10 @@
+package jsondoc
+
+import "github.com/lovego/jsondoc/decoder"
+
+// Unmarshal parses jsondoc text, like the output of MarshalIndent, and stores the result in the value pointed to by v.
+// Comments are ignored, and keys are matched to struct fields by the same naming rules as the encoder,
+// with the "*" prefix of pointer fields optional.
+func Unmarshal(data []byte, v interface{}) error {
+	return decoder.Unmarshal(data, v)
+}
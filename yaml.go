@@ -0,0 +1,38 @@
+package jsondoc
+
+import (
+	"bytes"
+
+	"github.com/lovego/jsondoc/ast"
+	"github.com/lovego/jsondoc/encoder"
+	"github.com/lovego/jsondoc/render"
+)
+
+// MarshalYAML is like MarshalIndent but produces YAML.
+// Struct fields become mapping keys, comments become trailing "# " comments,
+// and slices become "- " sequences. The "*" prefix of pointer fields is omitted,
+// so that the output can be used as a config template.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	return MarshalYAMLWithOptions(v, Options{})
+}
+
+// MarshalYAMLWithOptions is like MarshalYAML but uses opts to control the output.
+func MarshalYAMLWithOptions(v interface{}, opts Options) ([]byte, error) {
+	n, err := parseMarshaled(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	render.YAML(&buf, n)
+	return buf.Bytes(), nil
+}
+
+// parseMarshaled marshals v without pointer marks and parses the result.
+func parseMarshaled(v interface{}, opts Options) (*ast.Node, error) {
+	opts.OmitPointerMark = true
+	b, err := encoder.MarshalWithOptions(v, opts.encoderOptions())
+	if err != nil {
+		return nil, err
+	}
+	return ast.Parse(b)
+}
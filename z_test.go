@@ -2,7 +2,11 @@ package jsondoc
 
 import (
 	"fmt"
+	"net"
+	"strings"
 	"time"
+
+	"github.com/lovego/jsondoc/scanner"
 )
 
 func ExampleMarshalIndent_comments() {
@@ -105,3 +109,875 @@ func ExampleMarshalIndent_nil_pointer_to_anonymous_field() {
 	//   "*Time": "0001-01-01T00:00:00Z"
 	// } <nil>
 }
+
+func ExampleMarshalIndentWithOptions_deprecated() {
+	type user struct {
+		Name     string `c:"名称"`
+		Nickname string `c:"昵称" deprecated:"use Name instead"`
+		Age      int    `c:"Deprecated: use Birthday instead"`
+	}
+	b, err := MarshalIndentWithOptions(user{}, ``, `  `, Options{})
+	fmt.Println(string(b), err)
+	b, err = MarshalIndentWithOptions(user{}, ``, `  `, Options{OmitDeprecated: true})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Name": "",	 # 名称
+	//   "Nickname": "",	 # [DEPRECATED: use Name instead] 昵称
+	//   "Age": 0	 # [DEPRECATED: use Birthday instead]
+	// } <nil>
+	// {
+	//   "Name": ""	 # 名称
+	// } <nil>
+}
+
+func ExampleMarshalIndentWithOptions_api_version() {
+	type user struct {
+		Name     string `c:"名称"`
+		Nickname string `c:"昵称" until:"v3"`
+		Avatar   string `c:"头像" since:"v2.3"`
+	}
+	for _, version := range []string{"v2.2", "v2.10", "v3.0.0"} {
+		b, err := MarshalIndentWithOptions(user{}, ``, `  `, Options{APIVersion: version})
+		fmt.Println(string(b), err)
+	}
+
+	// Output:
+	// {
+	//   "Name": "",	 # 名称
+	//   "Nickname": ""	 # 昵称
+	// } <nil>
+	// {
+	//   "Name": "",	 # 名称
+	//   "Nickname": "",	 # 昵称
+	//   "Avatar": ""	 # 头像 (since v2.3)
+	// } <nil>
+	// {
+	//   "Name": "",	 # 名称
+	//   "Avatar": ""	 # 头像 (since v2.3)
+	// } <nil>
+}
+
+func ExampleMarshalIndentWithOptions_renamed_field() {
+	type priceV2 struct {
+		PriceOld int `json:"price" c:"价格（分）" until:"v3"`
+	}
+	type product struct {
+		Name string `c:"名称"`
+		priceV2
+		PriceNew float64 `json:"price" c:"价格（元）" since:"v3"`
+	}
+	for _, version := range []string{"v2", "v3", ""} {
+		b, err := MarshalIndentWithOptions(product{}, ``, `  `, Options{APIVersion: version})
+		fmt.Println(string(b), err)
+	}
+	b, err := MarshalIndentWithOptions(product{}, ``, `  `, Options{OmitVersionNotes: true})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Name": "",	 # 名称
+	//   "price": 0	 # 价格（分）
+	// } <nil>
+	// {
+	//   "Name": "",	 # 名称
+	//   "price": 0	 # 价格（元） (since v3)
+	// } <nil>
+	// {
+	//   "Name": "",	 # 名称
+	//   "price": 0	 # 价格（元） (since v3)
+	// } <nil>
+	// {
+	//   "Name": "",	 # 名称
+	//   "price": 0	 # 价格（元）
+	// } <nil>
+}
+
+func ExampleMarshalIndentWithOptions_views() {
+	type user struct {
+		Name     string `c:"名称"`
+		Password string `c:"密码" view:"internal"`
+		Role     string `c:"角色" view:"internal,admin"`
+	}
+	b, err := MarshalIndentWithOptions(user{}, ``, `  `, Options{Views: []string{"admin"}})
+	fmt.Println(string(b), err)
+	b, err = MarshalIndentWithOptions(user{}, ``, `  `, Options{Views: []string{"partner"}})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Name": "",	 # 名称
+	//   "Role": ""	 # 角色
+	// } <nil>
+	// {
+	//   "Name": ""	 # 名称
+	// } <nil>
+}
+
+func ExampleMarshalIndentWithOptions_path_comments() {
+	type item struct {
+		Sku   string  `c:"编码"`
+		Price float64 `c:"价格"`
+	}
+	type data struct {
+		Items  []item
+		Tags   map[string]string `c:"标签"`
+		ByCode map[string]item
+	}
+	b, err := MarshalIndentWithOptions(struct{ Data data }{}, ``, `  `, Options{PathComments: true})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Data": {	 # Data
+	//     "Items": [	 # Data.Items
+	//       {	 # Data.Items[]
+	//         "Sku": "",	 # 编码 (Data.Items[].Sku)
+	//         "Price": 0	 # 价格 (Data.Items[].Price)
+	//       }
+	//     ],
+	//     "Tags": {	 # 标签 (Data.Tags)
+	//       "": ""	 # Data.Tags.*
+	//     },
+	//     "ByCode": {	 # Data.ByCode
+	//       "": {	 # Data.ByCode.*
+	//         "Sku": "",	 # 编码 (Data.ByCode.*.Sku)
+	//         "Price": 0	 # 价格 (Data.ByCode.*.Price)
+	//       }
+	//     }
+	//   }
+	// } <nil>
+}
+
+type secret string
+
+func (s secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s + `"`), nil
+}
+
+type commentedPhone string
+
+func (p commentedPhone) MarshalJSON() ([]byte, error) {
+	return []byte("# 国内手机号\n\"" + p + "\""), nil
+}
+
+func ExampleMarshalIndentWithOptions_sensitive_marshaler() {
+	type account struct {
+		Phone commentedPhone `json:",sensitive" c:"手机号"`
+	}
+	b, err := MarshalIndentWithOptions(account{Phone: "13812345678"}, ``, `  `, Options{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Phone": "138****5678"	 # 手机号
+	// } <nil>
+}
+
+func ExampleMarshalIndentWithOptions_sensitive() {
+	type account struct {
+		Name     string            `c:"名称"`
+		Phone    string            `json:",sensitive" c:"手机号"`
+		Balance  float64           `json:",sensitive" c:"余额"`
+		Token    secret            `c:"令牌"`
+		Password string            `c:"密码"`
+		Keys     map[string]string `c:"密钥"`
+	}
+	b, err := MarshalIndentWithOptions(account{
+		Name: "a", Phone: "13812345678", Balance: 100, Token: "abc", Password: "123",
+		Keys: map[string]string{"apiToken": "xyz", "id": "1"},
+	}, ``, `  `, Options{SensitiveKeys: []string{"password", "*token"}})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Name": "a",	 # 名称
+	//   "Phone": "138****5678",	 # 手机号
+	//   "Balance": 0,	 # 余额
+	//   "Token": "***",	 # 令牌
+	//   "Password": "***",	 # 密码
+	//   "Keys": {	 # 密钥
+	//     "apiToken": "***",
+	//     "id": "1"
+	//   }
+	// } <nil>
+}
+
+func ExampleSchema() {
+	type node struct {
+		Name     string     `c:"名称" format:"hostname"`
+		Weight   int64      `json:",string,omitempty" c:"权重"`
+		Children []node     `c:"孩子"`
+		Created  *time.Time `c:"创建时间"`
+	}
+	b, err := Schema(node{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "$schema": "https://json-schema.org/draft/2020-12/schema",
+	//   "type": "object",
+	//   "properties": {
+	//     "Name": {
+	//       "type": "string",
+	//       "format": "hostname",
+	//       "description": "名称"
+	//     },
+	//     "Weight": {
+	//       "type": "string",
+	//       "description": "权重"
+	//     },
+	//     "Children": {
+	//       "type": "array",
+	//       "description": "孩子",
+	//       "items": {
+	//         "$ref": "#"
+	//       }
+	//     },
+	//     "Created": {
+	//       "description": "创建时间",
+	//       "anyOf": [
+	//         {
+	//           "type": "string",
+	//           "format": "date-time"
+	//         },
+	//         {
+	//           "type": "null"
+	//         }
+	//       ]
+	//     }
+	//   },
+	//   "required": [
+	//     "Name",
+	//     "Children",
+	//     "Created"
+	//   ]
+	// } <nil>
+}
+
+type Pet struct {
+	Name  string `c:"名称"`
+	Owner *User  `c:"主人"`
+}
+
+type User struct {
+	Name string `json:"name" c:"名称"`
+	Age  int    `json:"age,omitempty"`
+}
+
+func ExampleOpenAPIComponents() {
+	b, err := OpenAPIComponents("3.0", Pet{Name: "Tom"})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "components": {
+	//     "schemas": {
+	//       "Pet": {
+	//         "type": "object",
+	//         "properties": {
+	//           "Name": {
+	//             "type": "string",
+	//             "description": "名称"
+	//           },
+	//           "Owner": {
+	//             "description": "主人",
+	//             "nullable": true,
+	//             "allOf": [
+	//               {
+	//                 "$ref": "#/components/schemas/User"
+	//               }
+	//             ]
+	//           }
+	//         },
+	//         "required": [
+	//           "Name",
+	//           "Owner"
+	//         ],
+	//         "example": {
+	//           "Name": "Tom",
+	//           "Owner": {
+	//             "name": "",
+	//             "age": 0
+	//           }
+	//         }
+	//       },
+	//       "User": {
+	//         "type": "object",
+	//         "properties": {
+	//           "name": {
+	//             "type": "string",
+	//             "description": "名称"
+	//           },
+	//           "age": {
+	//             "type": "integer"
+	//           }
+	//         },
+	//         "required": [
+	//           "name"
+	//         ]
+	//       }
+	//     }
+	//   }
+	// } <nil>
+}
+
+func ExampleMarshalYAML() {
+	type server struct {
+		Host  string            `json:"host" c:"主机"`
+		Port  int               `json:"port" c:"端口"`
+		Tags  []string          `json:"tags" c:"标签"`
+		Extra map[string]string `json:"extra"`
+	}
+	type config struct {
+		Name    string    `json:"name" c:"名称"`
+		Debug   *bool     `json:"debug" c:"调试模式"`
+		Servers []server  `json:"servers" c:"服务器"`
+		Admin   *struct{} `json:"admin" c:"管理"`
+	}
+	b, err := MarshalYAML(config{Name: "demo: yes"})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// name: "demo: yes"  # 名称
+	// debug: false  # 调试模式
+	// servers:  # 服务器
+	//   - host: ""  # 主机
+	//     port: 0  # 端口
+	//     tags:  # 标签
+	//       - ""
+	//     extra:
+	//       "": ""
+	// admin: {}  # 管理
+	//  <nil>
+}
+
+func ExampleMarkdownTable() {
+	type item struct {
+		Sku   string  `json:"sku" c:"编码"`
+		Price float64 `json:"price,omitempty" c:"价格|元"`
+	}
+	type order struct {
+		ID      int64            `json:"id,string" c:"ID"`
+		Items   []item           `json:"items" c:"明细"`
+		Created time.Time        `json:"created" c:"创建时间"`
+		Extra   map[string]*item `json:"extra"`
+	}
+	b, err := MarkdownTable(order{ID: 1, Items: []item{{Sku: "a", Price: 1.5}}})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// | Path | Type | Required | Example | Description |
+	// | --- | --- | --- | --- | --- |
+	// | `id` | string | yes | `"1"` | ID |
+	// | `items` | object[] | yes |  | 明细 |
+	// | `items[].sku` | string | yes | `"a"` | 编码 |
+	// | `items[].price` | number | no | `1.5` | 价格\|元 |
+	// | `created` | string (date-time) | yes | `"0001-01-01T00:00:00Z"` | 创建时间 |
+	// | `extra` | map<string, object> | yes |  |  |
+	// | `extra.*.sku` | string | yes | `""` | 编码 |
+	// | `extra.*.price` | number | no | `0` | 价格\|元 |
+	//  <nil>
+}
+
+func ExampleTypeScriptWithOptions() {
+	type Account struct {
+		Name     string `json:"name" c:"名称"`
+		Password string `json:"password" c:"密码" view:"internal"`
+	}
+	b, err := TypeScriptWithOptions(Options{Views: []string{"public"}}, Account{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// export interface Account {
+	//   /** 名称 */
+	//   name: string;
+	// }
+	//  <nil>
+}
+
+func ExampleTypeScript() {
+	type Item struct {
+		Sku    string `json:"sku" c:"编码"`
+		Amount int64  `json:"amount,string"`
+	}
+	type Order struct {
+		ID      int               `json:"id" c:"ID"`
+		Items   []*Item           `json:"items" c:"明细"`
+		Buyer   *User             `json:"buyer"`
+		Remark  string            `json:"remark,omitempty" c:"备注" deprecated:"use note"`
+		Created time.Time         `json:"created"`
+		Extra   map[string]string `json:"extra-data"`
+		Address struct {
+			City string `json:"city"`
+		} `json:"address"`
+	}
+	b, err := TypeScript(Order{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// export interface Order {
+	//   /** ID */
+	//   id: number;
+	//   /** 明细 */
+	//   items: (Item | null)[];
+	//   buyer: User | null;
+	//   /** 备注 @deprecated use note */
+	//   remark?: string;
+	//   created: string;
+	//   "extra-data": Record<string, string>;
+	//   address: {
+	//     city: string;
+	//   };
+	// }
+	//
+	// export interface Item {
+	//   /** 编码 */
+	//   sku: string;
+	//   amount: string;
+	// }
+	//
+	// export interface User {
+	//   /** 名称 */
+	//   name: string;
+	//   age?: number;
+	// }
+	//  <nil>
+}
+
+func ExampleMarshalJSON5() {
+	type config struct {
+		Name    string   `json:"name" c:"名称"`
+		Plugins []string `json:"plugins" c:"插件"`
+		Env     map[string]string
+	}
+	b, err := MarshalJSON5(config{Name: `it's "ok"`, Plugins: []string{"a", "b"},
+		Env: map[string]string{"NODE_ENV": "dev", "x-y": "z"}},
+		JSON5Options{TrailingCommas: true, SingleQuotes: true})
+	fmt.Println(string(b), err)
+	fmt.Println(scanner.ValidateLenient(b))
+
+	// Output:
+	// {
+	//   name: 'it\'s "ok"', // 名称
+	//   plugins: [ // 插件
+	//     'a',
+	//     'b',
+	//   ],
+	//   Env: {
+	//     NODE_ENV: 'dev',
+	//     'x-y': 'z',
+	//   },
+	// }
+	//  <nil>
+	// <nil>
+}
+
+func ExampleMarshalTOML() {
+	type server struct {
+		Host string `json:"host" c:"主机"`
+		Port int    `json:"port" c:"端口"`
+	}
+	type config struct {
+		Name    string `json:"name" c:"名称"`
+		Tags    []string
+		Servers []server `json:"servers" c:"服务器"`
+		Log     struct {
+			Level string `json:"level" c:"日志级别"`
+			Next  *config
+		} `json:"log" c:"日志"`
+	}
+	b, err := MarshalTOML(config{Servers: []server{{"a", 1}, {"b", 2}}})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// # 名称
+	// name = ""
+	// Tags = [""]
+	//
+	// # 服务器
+	// [[servers]]
+	// # 主机
+	// host = "a"
+	// # 端口
+	// port = 1
+	//
+	// [[servers]]
+	// # 主机
+	// host = "b"
+	// # 端口
+	// port = 2
+	//
+	// # 日志
+	// [log]
+	// # 日志级别
+	// level = ""
+	//
+	// [log.Next]
+	// # 名称
+	// name = ""
+	// Tags = [""]
+	//
+	// # 服务器
+	// [[log.Next.servers]]
+	// # 主机
+	// host = ""
+	// # 端口
+	// port = 0
+	//
+	// # 日志
+	// [log.Next.log]
+	// # 日志级别
+	// level = ""
+	// # Next = null
+	//  <nil>
+}
+
+func ExampleMarshalTOML_nulls() {
+	type node struct {
+		Name     string  `c:"名称"`
+		Children []*node `c:"子节点"`
+	}
+	v := struct {
+		Values []interface{} `json:"values"`
+		Nodes  [][]node      `json:"nodes"`
+	}{Values: []interface{}{nil, 1}}
+	b, err := MarshalTOML(v)
+	fmt.Println(string(b), err)
+
+	// Output:
+	// values = [1]
+	// nodes = [[{ Name = "", Children = [{ Name = "" }] }]]
+	//  <nil>
+}
+
+func ExampleProto() {
+	type Order struct {
+		ID      int64             `json:"id" c:"ID"`
+		Items   []*Pet            `json:"items" c:"宠物"`
+		Remark  *string           `json:"remark" deprecated:""`
+		Created time.Time         `json:"createdAt"`
+		Labels  map[string]string `json:"labels"`
+		Address struct {
+			City string `json:"city"`
+		} `json:"shipping_address"`
+		Note string `json:"note" proto:"10"`
+	}
+	b, err := Proto("shop", Order{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// syntax = "proto3";
+	//
+	// package shop;
+	//
+	// import "google/protobuf/timestamp.proto";
+	//
+	// message Order {
+	//   // ID
+	//   int64 id = 1;
+	//   // 宠物
+	//   repeated Pet items = 2;
+	//   // [DEPRECATED]
+	//   optional string remark = 3 [deprecated = true];
+	//   google.protobuf.Timestamp created_at = 4;
+	//   map<string, string> labels = 5;
+	//   ShippingAddress shipping_address = 6 [json_name = "shipping_address"];
+	//   string note = 10;
+	//
+	//   message ShippingAddress {
+	//     string city = 1;
+	//   }
+	// }
+	//
+	// message Pet {
+	//   // 名称
+	//   string name = 1 [json_name = "Name"];
+	//   // 主人
+	//   User owner = 2 [json_name = "Owner"];
+	// }
+	//
+	// message User {
+	//   // 名称
+	//   string name = 1;
+	//   int64 age = 2;
+	// }
+	//  <nil>
+}
+
+func ExampleProto_collision() {
+	type Order struct {
+		ID     int64 `json:"id"`
+		UserID int64 `json:"userId" proto:"1"`
+	}
+	_, err := Proto("shop", Order{})
+	fmt.Println(err)
+
+	type User struct {
+		ID     int64  `json:"id"`
+		UserID int64  `json:"userId"`
+		UserId string `json:"user_id"`
+	}
+	_, err = Proto("shop", User{})
+	fmt.Println(err)
+
+	// Output:
+	// jsondoc: proto field number 1 of message Order is used by both id and userId
+	// jsondoc: proto fields userId and user_id of message User are both named user_id
+}
+
+func ExampleMarshalHTML() {
+	type item struct {
+		Sku string `json:"sku" c:"编码"`
+	}
+	type order struct {
+		Items []item          `json:"items" c:"商品"`
+		Tags  map[string]bool `json:"tags"`
+		Dot   string          `json:"a.b"`
+	}
+	b, err := MarshalHTML(order{})
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.Contains(line, `class="anchor"`) {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println(err)
+
+	// Output:
+	// <details open id="$"><summary><a class="anchor" href="#$">#</a>{<span class="ellipsis">…}</span></summary>
+	// <details open id="items"><summary><a class="anchor" href="#items">#</a><span class="key">&#34;items&#34;</span>: [<span class="ellipsis">…],</span><span class="comment"># 商品</span></summary>
+	// <details open id="items[0]"><summary><a class="anchor" href="#items%5B0%5D">#</a>{<span class="ellipsis">…}</span></summary>
+	// <div class="line" id="items[0].sku"><a class="anchor" href="#items%5B0%5D.sku">#</a><span class="key">&#34;sku&#34;</span>: <span class="string">&#34;&#34;</span><span class="comment"># 编码</span></div>
+	// <details open id="tags"><summary><a class="anchor" href="#tags">#</a><span class="key">&#34;tags&#34;</span>: {<span class="ellipsis">…},</span></summary>
+	// <div class="line" id="tags[&#34;&#34;]"><a class="anchor" href="#tags%5B%22%22%5D">#</a><span class="key">&#34;&#34;</span>: <span class="bool">false</span></div>
+	// <div class="line" id="[&#34;a.b&#34;]"><a class="anchor" href="#%5B%22a.b%22%5D">#</a><span class="key">&#34;a.b&#34;</span>: <span class="string">&#34;&#34;</span></div>
+	// <nil>
+}
+
+func ExampleMarshalTree() {
+	type server struct {
+		Host string `json:"host" c:"主机"`
+		Port int    `json:"port" c:"端口"`
+	}
+	type config struct {
+		Name    string   `json:"name" c:"名称"`
+		Servers []server `json:"servers" c:"服务器"`
+		Debug   bool     `json:"debug"`
+		Labels  map[string]string
+	}
+	b, err := MarshalTree(config{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// . (object)
+	// ├── name (string)          名称
+	// ├── servers (array)        服务器
+	// │   └── [0] (object)
+	// │       ├── host (string)  主机
+	// │       └── port (number)  端口
+	// ├── debug (bool)
+	// └── Labels (object)
+	//     └── "" (string)
+	//  <nil>
+}
+
+func ExampleMarshalTree_wideKeys() {
+	type item struct {
+		Name  string  `json:"名称" c:"商品名称"`
+		Price float64 `json:"price" c:"价格"`
+	}
+	b, err := MarshalTree(item{})
+	fmt.Println(string(b), err)
+
+	// Output:
+	// . (object)
+	// ├── 名称 (string)   商品名称
+	// └── price (number)  价格
+	//  <nil>
+}
+
+func ExampleStrip() {
+	type node struct {
+		Name string `c:"名称"`
+		Next *node  `c:"后一个"`
+	}
+	doc, _ := MarshalIndent(node{}, false, ``, `  `)
+	b, err := Strip(doc, true)
+	fmt.Println(string(b), err)
+
+	// Output:
+	// {
+	//   "Name": "",
+	//   "Next": {
+	//     "Name": "",
+	//     "Next": null
+	//   }
+	// } <nil>
+}
+
+func ExampleUnmarshal() {
+	type node struct {
+		Name  string `json:"name" c:"名称"`
+		Count int    `json:"count,string"`
+		Next  *node  `c:"后一个"`
+	}
+	var v node
+	err := Unmarshal([]byte(`{
+  "name": "a",  # 名称
+  "count": "1",
+  "*Next": {  # 后一个
+    "name": "b",  # 名称
+    "Next": null  # 后一个
+  }
+}`), &v)
+	fmt.Println(v.Name, v.Count, v.Next.Name, v.Next.Next, err)
+
+	err = Unmarshal([]byte(`{ "*Next": { "name": 1 } }`), &v)
+	fmt.Println(err)
+
+	// Output:
+	// a 1 b <nil> <nil>
+	// json: cannot unmarshal number into Go struct field node.Next.name of type string
+}
+
+type nullable struct{ Null bool }
+
+func (n *nullable) UnmarshalJSON(b []byte) error {
+	n.Null = string(b) == "null"
+	return nil
+}
+
+func ExampleUnmarshal_typeMismatch() {
+	var v struct {
+		IP   net.IP
+		Note nullable
+	}
+	err := Unmarshal([]byte(`{"IP": 1}`), &v)
+	fmt.Println(err)
+
+	err = Unmarshal([]byte(`{"IP": "127.0.0.1", "Note": null}`), &v)
+	fmt.Println(v.IP, v.Note.Null, err)
+
+	// Output:
+	// json: cannot unmarshal number into Go struct field .IP of type net.IP
+	// 127.0.0.1 true <nil>
+}
+
+func ExampleFormat() {
+	src := []byte(`{ # 商品
+    "sizes": [ 180, 200 ], # 尺寸
+    # 名称不能为空
+    "name": "沙发",   # 名称
+        "*owner": null,  # 所有者
+}
+`)
+	b, warnings, err := Format(src, FormatOptions{Indent: "  ", AlignComments: true, SortKeys: true, Lenient: true})
+	fmt.Print(string(b), warnings, err, "\n")
+
+	b, _, err = Format(src, FormatOptions{Indent: "\t", CommentSyntax: "/* */", Lenient: true})
+	fmt.Print(string(b), err, "\n")
+
+	// "/* */" comments are read back only with Lenient.
+	_, _, err = Format(b, FormatOptions{})
+	fmt.Println(err)
+	b, warnings, err = Format(b, FormatOptions{Indent: "  ", Lenient: true})
+	fmt.Print(string(b), err, "\n")
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+
+	// Output:
+	// {  # 商品
+	//   # 名称不能为空
+	//   "name": "沙发",  # 名称
+	//   "*owner": null,  # 所有者
+	//   "sizes": [
+	//     180,
+	//     200
+	//   ]  # 尺寸
+	// }
+	// [5:23: trailing comma] <nil>
+	// {	 /* 商品 */
+	// 	"sizes": [
+	// 		180,
+	// 		200
+	// 	],	 /* 尺寸 */
+	// 	/* 名称不能为空 */
+	// 	"name": "沙发",	 /* 名称 */
+	// 	"*owner": null	 /* 所有者 */
+	// }
+	// <nil>
+	// invalid character '/' looking for beginning of object key string
+	// {	 # 商品
+	//   "sizes": [
+	//     180,
+	//     200
+	//   ],	 # 尺寸
+	//   # 名称不能为空
+	//   "name": "沙发",	 # 名称
+	//   "*owner": null	 # 所有者
+	// }
+	// <nil>
+	// 1:4: "/* */" comment
+	// 5:6: "/* */" comment
+	// 6:2: "/* */" comment
+	// 7:17: "/* */" comment
+	// 8:18: "/* */" comment
+}
+
+func ExampleCheck() {
+	type item struct {
+		SKU string `json:"sku" c:"商品编码"`
+		Qty int    `json:"qty" c:"数量"`
+	}
+	type order struct {
+		ID    int64   `json:"id" c:"订单ID"`
+		Items []item  `json:"items" c:"明细"`
+		Note  *string `json:"note,omitempty" c:"备注"`
+	}
+	doc := []byte(`{
+  "id": "1001",       # 订单号
+  "items": [          # 明细
+    {
+      # 编码以字母开头
+      "sku": "A-01",  # 商品编码
+      "count": 2      # 数量
+    }
+  ],
+  "note": "加急"      # 备注
+}`)
+	for _, problem := range Check(doc, order{}) {
+		fmt.Println(problem)
+	}
+
+	// Output:
+	// id: comment "订单号", want "订单ID"
+	// id: got string, want number (int64)
+	// items[].count: unknown key
+	// items[].qty: missing field
+	// note: key should be "*note"
+}
+
+func ExampleCheckWithOptions() {
+	type user struct {
+		Name     string `json:"name" c:"名称"`
+		Nickname string `json:"nickname" c:"昵称" until:"v3"`
+		Avatar   string `json:"avatar" c:"头像" since:"v2.3"`
+		Password string `json:"password" c:"密码" view:"internal"`
+	}
+	doc := []byte(`{
+  "name": "",    # 名称
+  "avatar": "",  # 头像
+  "password": "" # 密码
+}`)
+	opts := Options{APIVersion: "v3", Views: []string{"public"}, OmitVersionNotes: true}
+	for _, problem := range CheckWithOptions(doc, user{}, opts) {
+		fmt.Println(problem)
+	}
+	fmt.Println(Check(doc, user{}))
+
+	// Output:
+	// password: unknown key
+	// [avatar: comment "头像", want "头像 (since v2.3)" nickname: missing field]
+}